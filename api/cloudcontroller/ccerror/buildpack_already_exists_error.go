@@ -0,0 +1,11 @@
+package ccerror
+
+// BuildpackAlreadyExistsError is returned when a buildpack cannot be given a
+// name because another buildpack with that name and stack already exists.
+type BuildpackAlreadyExistsError struct {
+	Message string
+}
+
+func (e BuildpackAlreadyExistsError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,15 @@
+package ccerror
+
+import "fmt"
+
+// BuildpackBitsNotReadyError is returned when the Cloud Controller keeps
+// rejecting a buildpack upload with a 409 Conflict because the buildpack is
+// not yet ready to receive bits.
+type BuildpackBitsNotReadyError struct {
+	BuildpackGUID string
+	Attempts      int
+}
+
+func (e BuildpackBitsNotReadyError) Error() string {
+	return fmt.Sprintf("Buildpack (%s) was not ready to receive bits after %d attempt(s)", e.BuildpackGUID, e.Attempts)
+}
@@ -0,0 +1,17 @@
+package ccerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildpackInUseError is returned when a buildpack cannot be deleted because
+// applications use it.
+type BuildpackInUseError struct {
+	BuildpackName string
+	AppNames      []string
+}
+
+func (e BuildpackInUseError) Error() string {
+	return fmt.Sprintf("Buildpack '%s' is in use by apps: %s", e.BuildpackName, strings.Join(e.AppNames, ", "))
+}
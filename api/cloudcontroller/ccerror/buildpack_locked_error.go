@@ -0,0 +1,13 @@
+package ccerror
+
+import "fmt"
+
+// BuildpackLockedError is returned when bits are uploaded to a locked
+// buildpack.
+type BuildpackLockedError struct {
+	Name string
+}
+
+func (e BuildpackLockedError) Error() string {
+	return fmt.Sprintf("Buildpack %s is locked", e.Name)
+}
@@ -0,0 +1,26 @@
+package ccerror
+
+import "fmt"
+
+// BuildpackNotFoundError is returned when a buildpack matching the provided
+// criteria cannot be found. Position is only set for lookups by position.
+type BuildpackNotFoundError struct {
+	Name     string
+	Stack    string
+	Position int
+}
+
+func (e BuildpackNotFoundError) Error() string {
+	subject := fmt.Sprintf("Buildpack '%s'", e.Name)
+	if e.Name == "" {
+		subject = "Buildpack"
+		if e.Position > 0 {
+			subject = fmt.Sprintf("Buildpack at position %d", e.Position)
+		}
+	}
+
+	if e.Stack == "" {
+		return fmt.Sprintf("%s not found", subject)
+	}
+	return fmt.Sprintf("%s with stack '%s' not found", subject, e.Stack)
+}
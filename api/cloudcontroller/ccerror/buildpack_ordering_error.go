@@ -0,0 +1,16 @@
+package ccerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildpackOrderingError is returned when buildpack positions do not form the
+// sequence 1 to N. It contains every problem found, not just the first.
+type BuildpackOrderingError struct {
+	Problems []string
+}
+
+func (e BuildpackOrderingError) Error() string {
+	return fmt.Sprintf("Buildpack positions are invalid: %s", strings.Join(e.Problems, "; "))
+}
@@ -0,0 +1,16 @@
+package ccerror
+
+import "fmt"
+
+// BuildpackRestoreRollbackError is returned when restoring a buildpack fails
+// after it was created and the created buildpack could not be deleted, so it
+// was left behind without bits.
+type BuildpackRestoreRollbackError struct {
+	BuildpackGUID string
+	Err           error
+	RollbackErr   error
+}
+
+func (e BuildpackRestoreRollbackError) Error() string {
+	return fmt.Sprintf("%s\nThe buildpack (%s) created for the restore could not be deleted: %s", e.Err, e.BuildpackGUID, e.RollbackErr)
+}
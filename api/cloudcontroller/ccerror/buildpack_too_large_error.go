@@ -0,0 +1,25 @@
+package ccerror
+
+import "fmt"
+
+// BuildpackTooLargeError is returned when the Cloud Controller rejects
+// buildpack bits for exceeding its maximum size.
+type BuildpackTooLargeError struct {
+	// Size is the size in bytes of the buildpack that was uploaded, or -1 if
+	// it was unknown.
+	Size int64
+
+	// Limit is the maximum size in bytes accepted by the Cloud Controller, or
+	// 0 if the response did not include it.
+	Limit int64
+}
+
+func (e BuildpackTooLargeError) Error() string {
+	if e.Limit > 0 {
+		return fmt.Sprintf("Buildpack of %d bytes exceeds the maximum size of %d bytes", e.Size, e.Limit)
+	}
+	if e.Size >= 0 {
+		return fmt.Sprintf("Buildpack of %d bytes exceeds the maximum size", e.Size)
+	}
+	return "Buildpack exceeds the maximum size"
+}
@@ -0,0 +1,26 @@
+package ccerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildpackUploadFailure is a buildpack upload that failed in a batch.
+type BuildpackUploadFailure struct {
+	BuildpackGUID string
+	Err           error
+}
+
+// BuildpackUploadsError is returned when uploads in a batch that continues on
+// error fail. It contains every failure, in the order of the batch.
+type BuildpackUploadsError struct {
+	Failures []BuildpackUploadFailure
+}
+
+func (e BuildpackUploadsError) Error() string {
+	var failures []string
+	for _, failure := range e.Failures {
+		failures = append(failures, fmt.Sprintf("%s: %s", failure.BuildpackGUID, failure.Err))
+	}
+	return fmt.Sprintf("Failed to upload %d buildpacks: %s", len(e.Failures), strings.Join(failures, "; "))
+}
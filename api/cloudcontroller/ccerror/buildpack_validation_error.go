@@ -0,0 +1,17 @@
+package ccerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildpackValidationError is returned when a buildpack fails local
+// validation before being sent to the Cloud Controller. It contains every
+// problem found, not just the first.
+type BuildpackValidationError struct {
+	Problems []string
+}
+
+func (e BuildpackValidationError) Error() string {
+	return fmt.Sprintf("Buildpack is invalid: %s", strings.Join(e.Problems, "; "))
+}
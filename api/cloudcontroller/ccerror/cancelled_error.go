@@ -0,0 +1,9 @@
+package ccerror
+
+// CancelledError is returned when a request is stopped by the caller before it
+// completes. Results retrieved before it was stopped may be returned with it.
+type CancelledError struct{}
+
+func (CancelledError) Error() string {
+	return "Request cancelled"
+}
@@ -0,0 +1,18 @@
+package ccerror
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConcurrentModificationError is returned when a buildpack was modified after
+// the caller read it, so an update would overwrite the other change.
+type ConcurrentModificationError struct {
+	GUID              string
+	ExpectedUpdatedAt time.Time
+	ActualUpdatedAt   time.Time
+}
+
+func (e ConcurrentModificationError) Error() string {
+	return fmt.Sprintf("Buildpack %s was modified at %s, after it was read at %s", e.GUID, e.ActualUpdatedAt.Format(time.RFC3339), e.ExpectedUpdatedAt.Format(time.RFC3339))
+}
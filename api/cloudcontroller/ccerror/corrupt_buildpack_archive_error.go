@@ -0,0 +1,21 @@
+package ccerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CorruptBuildpackArchiveError is returned when a buildpack zip cannot be
+// read, or when the contents of its entries do not match the checksums stored
+// in the zip. Entries lists the corrupt entries; it is empty when the zip
+// itself cannot be read.
+type CorruptBuildpackArchiveError struct {
+	Entries []string
+}
+
+func (e CorruptBuildpackArchiveError) Error() string {
+	if len(e.Entries) == 0 {
+		return "Buildpack zip is corrupt"
+	}
+	return fmt.Sprintf("Buildpack zip is corrupt: %s", strings.Join(e.Entries, ", "))
+}
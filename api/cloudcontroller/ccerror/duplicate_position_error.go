@@ -0,0 +1,18 @@
+package ccerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DuplicatePositionError is returned when more than one buildpack occupies
+// the same position on a stack.
+type DuplicatePositionError struct {
+	Position int
+	Stack    string
+	GUIDs    []string
+}
+
+func (e DuplicatePositionError) Error() string {
+	return fmt.Sprintf("Multiple buildpacks found at position %d with stack '%s': %s", e.Position, e.Stack, strings.Join(e.GUIDs, ", "))
+}
@@ -0,0 +1,9 @@
+package ccerror
+
+// EmptyBuildpackGUIDError is returned when an operation on a single buildpack
+// is given an empty buildpack GUID.
+type EmptyBuildpackGUIDError struct{}
+
+func (EmptyBuildpackGUIDError) Error() string {
+	return "Buildpack GUID must not be empty"
+}
@@ -0,0 +1,16 @@
+package ccerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IncompleteBuildpackError is returned when a buildpack zip is missing files
+// that are required to stage applications.
+type IncompleteBuildpackError struct {
+	MissingFiles []string
+}
+
+func (e IncompleteBuildpackError) Error() string {
+	return fmt.Sprintf("Buildpack is missing required files: %s", strings.Join(e.MissingFiles, ", "))
+}
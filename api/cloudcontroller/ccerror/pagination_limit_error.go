@@ -0,0 +1,14 @@
+package ccerror
+
+import "fmt"
+
+// PaginationLimitError is returned when a paginated request reaches its page
+// limit while more pages remain.
+type PaginationLimitError struct {
+	MaxPages int
+	NextURL  string
+}
+
+func (e PaginationLimitError) Error() string {
+	return fmt.Sprintf("Stopped after %d pages with more pages remaining (next page: %s)", e.MaxPages, e.NextURL)
+}
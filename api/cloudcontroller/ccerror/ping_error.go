@@ -0,0 +1,34 @@
+package ccerror
+
+import "fmt"
+
+// PingFailureReason classifies why a ping of a Cloud Controller endpoint
+// failed.
+type PingFailureReason string
+
+const (
+	// PingFailureAuth means the request was not authenticated or not
+	// authorized.
+	PingFailureAuth PingFailureReason = "auth"
+
+	// PingFailureConnectivity means the Cloud Controller could not be reached.
+	PingFailureConnectivity PingFailureReason = "connectivity"
+
+	// PingFailureServer means the Cloud Controller responded with a server
+	// error.
+	PingFailureServer PingFailureReason = "server"
+
+	// PingFailureUnknown means the failure could not be classified.
+	PingFailureUnknown PingFailureReason = "unknown"
+)
+
+// PingError is returned when a preflight check of a Cloud Controller endpoint
+// fails. It wraps the underlying error.
+type PingError struct {
+	Reason PingFailureReason
+	Err    error
+}
+
+func (e PingError) Error() string {
+	return fmt.Sprintf("Cloud Controller endpoint check failed (%s): %s", e.Reason, e.Err)
+}
@@ -0,0 +1,13 @@
+package ccerror
+
+import "fmt"
+
+// RedirectLoopError is returned when a request is redirected back to a URL
+// that was already requested with the same method.
+type RedirectLoopError struct {
+	URL string
+}
+
+func (e RedirectLoopError) Error() string {
+	return fmt.Sprintf("Redirect loop detected: %s was already requested", e.URL)
+}
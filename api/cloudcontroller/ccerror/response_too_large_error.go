@@ -0,0 +1,13 @@
+package ccerror
+
+import "fmt"
+
+// ResponseTooLargeError is returned when a response body exceeds the maximum
+// size the client accepts.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("Response body exceeds the maximum size of %d bytes", e.Limit)
+}
@@ -0,0 +1,39 @@
+package ccerror
+
+import (
+	"net"
+	"net/http"
+)
+
+// IsRetryable returns true if the request that returned err may succeed when
+// made again. Server errors (5xx), 429 Too Many Requests, timeouts, and
+// transient network errors such as reset connections are retryable. Other
+// client errors (4xx) and errors found before a request is sent are not.
+//
+// Only the error is classified; callers decide whether the request itself is
+// safe to repeat.
+func IsRetryable(err error) bool {
+	switch e := err.(type) {
+	case V2UnexpectedResponseError:
+		return IsRetryableStatusCode(e.ResponseCode)
+	case V3UnexpectedResponseError:
+		return IsRetryableStatusCode(e.ResponseCode)
+	case UnknownHTTPSourceError:
+		return IsRetryableStatusCode(e.StatusCode)
+	case RawHTTPStatusError:
+		return IsRetryableStatusCode(e.StatusCode)
+	case ServiceUnavailableError, UploadTimeoutError:
+		return true
+	case RequestError:
+		if netErr, ok := e.Err.(net.Error); ok {
+			return netErr.Timeout() || netErr.Temporary()
+		}
+	}
+	return false
+}
+
+// IsRetryableStatusCode returns true if a response with the status code may
+// succeed when the request is made again.
+func IsRetryableStatusCode(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,36 @@
+package ccerror_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsRetryable", func() {
+	DescribeTable("classifies errors",
+		func(err error, retryable bool) {
+			Expect(IsRetryable(err)).To(Equal(retryable))
+		},
+		Entry("a V2 500", V2UnexpectedResponseError{ResponseCode: http.StatusInternalServerError}, true),
+		Entry("a V2 501", V2UnexpectedResponseError{ResponseCode: http.StatusNotImplemented}, true),
+		Entry("a V3 503", V3UnexpectedResponseError{ResponseCode: http.StatusServiceUnavailable}, true),
+		Entry("a raw 429", RawHTTPStatusError{StatusCode: http.StatusTooManyRequests}, true),
+		Entry("a raw 409", RawHTTPStatusError{StatusCode: http.StatusConflict}, false),
+		Entry("a 404 without a Cloud Controller body", UnknownHTTPSourceError{StatusCode: http.StatusNotFound}, false),
+		Entry("a network timeout", RequestError{Err: &url.Error{Op: "Get", URL: "https://api.example.com", Err: timeoutError{}}}, true),
+		Entry("a refused connection", RequestError{Err: &url.Error{Op: "Get", URL: "https://api.example.com", Err: errors.New("connection refused")}}, false),
+		Entry("no error", nil, false),
+	)
+})
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
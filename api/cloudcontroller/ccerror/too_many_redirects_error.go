@@ -0,0 +1,14 @@
+package ccerror
+
+import "fmt"
+
+// TooManyRedirectsError is returned when a request is redirected more times
+// than allowed.
+type TooManyRedirectsError struct {
+	Limit int
+	URL   string
+}
+
+func (e TooManyRedirectsError) Error() string {
+	return fmt.Sprintf("Stopped after %d redirects at %s", e.Limit, e.URL)
+}
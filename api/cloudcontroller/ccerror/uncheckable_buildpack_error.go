@@ -0,0 +1,14 @@
+package ccerror
+
+import "fmt"
+
+// UncheckableBuildpackError is returned when a buildpack zip cannot be
+// checked before it is uploaded because its reader is not an io.ReaderAt or
+// its length is unknown.
+type UncheckableBuildpackError struct {
+	Check string
+}
+
+func (e UncheckableBuildpackError) Error() string {
+	return fmt.Sprintf("Buildpack cannot be checked for %s: the zip must be readable at an offset and have a known length", e.Check)
+}
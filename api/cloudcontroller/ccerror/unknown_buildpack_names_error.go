@@ -0,0 +1,20 @@
+package ccerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnknownBuildpackNamesError is returned when buildpack names cannot be
+// resolved to buildpacks on a stack.
+type UnknownBuildpackNamesError struct {
+	Names []string
+	Stack string
+}
+
+func (e UnknownBuildpackNamesError) Error() string {
+	if e.Stack == "" {
+		return fmt.Sprintf("Buildpacks not found: %s", strings.Join(e.Names, ", "))
+	}
+	return fmt.Sprintf("Buildpacks not found with stack '%s': %s", e.Stack, strings.Join(e.Names, ", "))
+}
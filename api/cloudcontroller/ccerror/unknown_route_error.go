@@ -0,0 +1,13 @@
+package ccerror
+
+import "fmt"
+
+// UnknownRouteError is returned when a route override names a request that
+// the client does not make.
+type UnknownRouteError struct {
+	Name string
+}
+
+func (e UnknownRouteError) Error() string {
+	return fmt.Sprintf("Cannot override unknown route '%s'", e.Name)
+}
@@ -0,0 +1,17 @@
+package ccerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnsupportedFilterOperatorError is returned when a filter that the client
+// applies itself uses an operator it cannot evaluate.
+type UnsupportedFilterOperatorError struct {
+	FilterType string
+	Operator   string
+}
+
+func (e UnsupportedFilterOperatorError) Error() string {
+	return fmt.Sprintf("The %s filter does not support the '%s' operator", e.FilterType, strings.TrimSpace(e.Operator))
+}
@@ -0,0 +1,13 @@
+package ccerror
+
+import "fmt"
+
+// UploadPanicError is returned when reading the contents of an upload panics.
+type UploadPanicError struct {
+	// Value is the value the panic was raised with.
+	Value interface{}
+}
+
+func (e UploadPanicError) Error() string {
+	return fmt.Sprintf("upload aborted due to a panic while reading its contents: %v", e.Value)
+}
@@ -0,0 +1,16 @@
+package ccerror
+
+import (
+	"fmt"
+	"time"
+)
+
+// UploadTimeoutError is returned when an upload does not finish within the
+// configured upload timeout.
+type UploadTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e UploadTimeoutError) Error() string {
+	return fmt.Sprintf("upload did not finish within the timeout of %s", e.Timeout)
+}
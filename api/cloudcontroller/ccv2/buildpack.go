@@ -1,15 +1,32 @@
 package ccv2
 
 import (
+	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"net/url"
+	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/internal"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccversion"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Buildpack represents a Cloud Controller Buildpack.
@@ -18,162 +35,1992 @@ type Buildpack struct {
 	GUID     string `json:"guid,omitempty"`
 	Name     string `json:"name"`
 	Position int    `json:"position,omitempty"`
+	Stack    string `json:"stack,omitempty"`
+
+	// Locked is true when the Cloud Controller rejects new bits for the
+	// buildpack. CreateBuildpack does not send it.
+	Locked bool `json:"locked,omitempty"`
+
+	// Filename is the name of the file the buildpack's bits were uploaded
+	// from. It is empty until bits are uploaded and is only read from
+	// responses.
+	Filename string `json:"filename,omitempty"`
+
+	// CreatedAt is the time the Cloud Controller created the buildpack. It is
+	// read from the metadata of responses and is zero for buildpacks that
+	// were not returned by the Cloud Controller. GetRecentBuildpacks and the
+	// created_at filters rely on it being set.
+	CreatedAt time.Time `json:"-"`
+
+	// UpdatedAt is the time the Cloud Controller last updated the buildpack.
+	// It is zero if the buildpack was never updated.
+	UpdatedAt time.Time `json:"-"`
+
+	// Extra holds entity fields returned by the Cloud Controller that are not
+	// decoded into the typed fields above. It is nil when there are none.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// knownBuildpackEntityFields are the entity fields decoded into typed
+// Buildpack fields.
+var knownBuildpackEntityFields = []string{"name", "position", "enabled", "stack", "locked", "filename"}
+
+// buildpackSizeLimitRegexp matches a size such as "1024 MB" in the
+// description of a Cloud Controller error.
+var buildpackSizeLimitRegexp = regexp.MustCompile(`(?i)(\d+)\s*(bytes|b|kb|mb|gb)\b`)
+
+// maxBuildpackNamesPerQuery limits how many names GetBuildpacksByNames puts
+// in a single request to keep the request URI short.
+const maxBuildpackNamesPerQuery = 50
+
+// maxResultsPerPage is the largest page size the Cloud Controller accepts.
+const maxResultsPerPage = 100
+
+// multipartBoundaryLength is the length of the random boundaries that
+// multipart.Writer generates.
+var multipartBoundaryLength = len(multipart.NewWriter(ioutil.Discard).Boundary())
+
+// quoteEscaper escapes a multipart file name the same way as
+// multipart.Writer.CreateFormFile.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// buildpackNameRegexp matches the names the Cloud Controller accepts for
+// buildpacks.
+var buildpackNameRegexp = regexp.MustCompile(`^[-\w]+$`)
+
 func (buildpack *Buildpack) UnmarshalJSON(data []byte) error {
 	var alias struct {
 		Metadata struct {
-			GUID string `json:"guid"`
+			GUID      string     `json:"guid"`
+			CreatedAt *time.Time `json:"created_at"`
+			UpdatedAt *time.Time `json:"updated_at"`
 		} `json:"metadata"`
 		Entity struct {
 			Name     string `json:"name"`
 			Position int    `json:"position"`
 			Enabled  bool   `json:"enabled"`
+			Stack    string `json:"stack"`
+			Locked   bool   `json:"locked"`
+			Filename string `json:"filename"`
 		} `json:"entity"`
 	}
-	err := json.Unmarshal(data, &alias)
+	err := json.Unmarshal(data, &alias)
+	if err != nil {
+		return err
+	}
+
+	buildpack.CreatedAt = time.Time{}
+	if alias.Metadata.CreatedAt != nil {
+		buildpack.CreatedAt = *alias.Metadata.CreatedAt
+	}
+	buildpack.UpdatedAt = time.Time{}
+	if alias.Metadata.UpdatedAt != nil {
+		buildpack.UpdatedAt = *alias.Metadata.UpdatedAt
+	}
+	buildpack.Enabled = alias.Entity.Enabled
+	buildpack.Filename = alias.Entity.Filename
+	buildpack.GUID = alias.Metadata.GUID
+	buildpack.Locked = alias.Entity.Locked
+	buildpack.Name = alias.Entity.Name
+	buildpack.Position = alias.Entity.Position
+	buildpack.Stack = alias.Entity.Stack
+
+	var rawEntity struct {
+		Entity map[string]json.RawMessage `json:"entity"`
+	}
+	err = json.Unmarshal(data, &rawEntity)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range knownBuildpackEntityFields {
+		delete(rawEntity.Entity, field)
+	}
+	buildpack.Extra = nil
+	if len(rawEntity.Entity) > 0 {
+		buildpack.Extra = rawEntity.Entity
+	}
+
+	return nil
+}
+
+// buildpackCreateBody is the body the Cloud Controller accepts when creating a
+// buildpack.
+type buildpackCreateBody struct {
+	Enabled  bool   `json:"enabled"`
+	Name     string `json:"name"`
+	Position int    `json:"position,omitempty"`
+	Stack    string `json:"stack,omitempty"`
+}
+
+// buildpackUpdateBody is the body the Cloud Controller accepts when updating a
+// buildpack. Omitted fields keep their current values.
+type buildpackUpdateBody struct {
+	Enabled  bool   `json:"enabled"`
+	Locked   bool   `json:"locked"`
+	Name     string `json:"name,omitempty"`
+	Position int    `json:"position,omitempty"`
+	Stack    string `json:"stack,omitempty"`
+}
+
+// ToCreateBody returns the JSON body for creating the buildpack. A zero
+// Position is omitted so the Cloud Controller places the buildpack last.
+func (buildpack Buildpack) ToCreateBody() ([]byte, error) {
+	return json.Marshal(buildpackCreateBody{
+		Enabled:  buildpack.Enabled,
+		Name:     buildpack.Name,
+		Position: buildpack.Position,
+		Stack:    buildpack.Stack,
+	})
+}
+
+// ToUpdateBody returns the JSON body for updating the buildpack. The GUID
+// belongs in the request URL. An empty Name or Stack and a zero Position are
+// omitted so the current values are kept.
+func (buildpack Buildpack) ToUpdateBody() ([]byte, error) {
+	return json.Marshal(buildpackUpdateBody{
+		Enabled:  buildpack.Enabled,
+		Locked:   buildpack.Locked,
+		Name:     buildpack.Name,
+		Position: buildpack.Position,
+		Stack:    buildpack.Stack,
+	})
+}
+
+// Clone returns a deep copy of the buildpack that shares no maps or slices
+// with the original.
+func (buildpack Buildpack) Clone() Buildpack {
+	clone := buildpack
+	if buildpack.Extra != nil {
+		clone.Extra = make(map[string]json.RawMessage, len(buildpack.Extra))
+		for field, value := range buildpack.Extra {
+			clone.Extra[field] = append(json.RawMessage(nil), value...)
+		}
+	}
+	return clone
+}
+
+// String returns a short, human readable description of the buildpack. The
+// GUID is truncated to its first 8 characters.
+func (buildpack Buildpack) String() string {
+	guid := buildpack.GUID
+	if len(guid) > 8 {
+		guid = guid[:8] + "..."
+	}
+	return fmt.Sprintf("%s (pos=%d, stack=%s, enabled=%t, locked=%t, guid=%s)", buildpack.Name, buildpack.Position, buildpack.Stack, buildpack.Enabled, buildpack.Locked, guid)
+}
+
+// Validate checks the buildpack for problems the Cloud Controller would
+// reject. All problems found are returned in a
+// ccerror.BuildpackValidationError.
+func (buildpack Buildpack) Validate() error {
+	problems := buildpack.validationProblems()
+	if len(problems) > 0 {
+		return ccerror.BuildpackValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (buildpack Buildpack) validationProblems() []string {
+	var problems []string
+
+	if buildpack.Name == "" {
+		problems = append(problems, "name must not be empty")
+	} else if !buildpackNameRegexp.MatchString(buildpack.Name) {
+		problems = append(problems, fmt.Sprintf("name '%s' must only contain alphanumeric characters, underscores, and dashes", buildpack.Name))
+	}
+
+	if buildpack.Position < 0 {
+		problems = append(problems, fmt.Sprintf("position %d must not be negative", buildpack.Position))
+	}
+
+	return problems
+}
+
+// Buildpacks is a list of buildpacks.
+type Buildpacks []Buildpack
+
+// ValidateOrdering checks that the positions of the buildpacks are exactly
+// 1 to N, where N is the number of buildpacks, with no gaps or duplicates.
+// Positions are checked across all of the buildpacks, so to check a single
+// stack only pass that stack's buildpacks. All problems found are returned in
+// a ccerror.BuildpackOrderingError.
+func (buildpacks Buildpacks) ValidateOrdering() error {
+	var problems []string
+
+	namesByPosition := map[int][]string{}
+	for _, buildpack := range buildpacks {
+		switch {
+		case buildpack.Position <= 0:
+			problems = append(problems, fmt.Sprintf("buildpack '%s' has position %d, which must be positive", buildpack.Name, buildpack.Position))
+		case buildpack.Position > len(buildpacks):
+			problems = append(problems, fmt.Sprintf("buildpack '%s' has position %d, which is greater than the number of buildpacks (%d)", buildpack.Name, buildpack.Position, len(buildpacks)))
+		default:
+			namesByPosition[buildpack.Position] = append(namesByPosition[buildpack.Position], buildpack.Name)
+		}
+	}
+
+	var missing []string
+	for position := 1; position <= len(buildpacks); position++ {
+		names := namesByPosition[position]
+		switch {
+		case len(names) == 0:
+			missing = append(missing, strconv.Itoa(position))
+		case len(names) > 1:
+			problems = append(problems, fmt.Sprintf("position %d is shared by buildpacks '%s'", position, strings.Join(names, "', '")))
+		}
+	}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("no buildpack has position %s", strings.Join(missing, ", ")))
+	}
+
+	if len(problems) > 0 {
+		return ccerror.BuildpackOrderingError{Problems: problems}
+	}
+	return nil
+}
+
+// CreateBuildpack creates a new buildpack.
+func (client *Client) CreateBuildpack(buildpack Buildpack) (Buildpack, Warnings, error) {
+	if client.validateBuildpacks {
+		err := client.validateBuildpack(buildpack)
+		if err != nil {
+			return Buildpack{}, nil, err
+		}
+	}
+
+	body, err := buildpack.ToCreateBody()
+	if err != nil {
+		return Buildpack{}, nil, err
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.PostBuildpackRequest,
+		Body:        bytes.NewReader(body),
+	})
+	if err != nil {
+		return Buildpack{}, nil, err
+	}
+
+	var createdBuildpack Buildpack
+	response := cloudcontroller.Response{
+		Result:      &createdBuildpack,
+		MaxBodySize: client.maxBuildpackResponseSize,
+	}
+
+	err = client.makeBuildpackWrite(request, &response)
+	if err != nil {
+		return Buildpack{}, response.Warnings, buildpackWriteError(err, response)
+	}
+
+	return createdBuildpack, response.Warnings, nil
+}
+
+// CreateBuildpackAtEnd creates a new buildpack positioned after all existing
+// buildpacks. Any Position set on the provided buildpack is ignored.
+func (client *Client) CreateBuildpackAtEnd(buildpack Buildpack) (Buildpack, Warnings, error) {
+	maxPosition, warnings, err := client.getMaxBuildpackPosition()
+	if err != nil {
+		return Buildpack{}, warnings, err
+	}
+
+	buildpack.Position = maxPosition + 1
+	createdBuildpack, createWarnings, err := client.CreateBuildpack(buildpack)
+	return createdBuildpack, append(warnings, createWarnings...), err
+}
+
+// DeleteBuildpackSafe deletes the buildpack with the provided GUID unless
+// an app is configured to use it, in which case a ccerror.BuildpackInUseError
+// listing those apps is returned. The check is best effort: apps are matched
+// on the buildpack name they were pushed with, so apps using a detected
+// buildpack are not found. If force is true, the check is skipped.
+func (client *Client) DeleteBuildpackSafe(guid string, force bool) (Warnings, error) {
+	if force {
+		return client.DeleteBuildpack(guid)
+	}
+
+	var allWarnings Warnings
+
+	buildpack, warnings, err := client.GetBuildpack(guid)
+	allWarnings = append(allWarnings, warnings...)
+	if err != nil {
+		return allWarnings, err
+	}
+
+	apps, warnings, err := client.GetApplications()
+	allWarnings = append(allWarnings, warnings...)
+	if err != nil {
+		return allWarnings, err
+	}
+
+	var appNames []string
+	for _, app := range apps {
+		if app.Buildpack.IsSet && buildpackNamesMatch(app.Buildpack.Value, buildpack.Name) {
+			appNames = append(appNames, app.Name)
+		}
+	}
+	if len(appNames) > 0 {
+		return allWarnings, ccerror.BuildpackInUseError{
+			BuildpackName: buildpack.Name,
+			AppNames:      appNames,
+		}
+	}
+
+	warnings, err = client.DeleteBuildpack(guid)
+	allWarnings = append(allWarnings, warnings...)
+	return allWarnings, err
+}
+
+// DeleteBuildpack deletes the buildpack with the provided GUID.
+func (client *Client) DeleteBuildpack(guid string) (Warnings, error) {
+	if guid == "" {
+		return nil, ccerror.EmptyBuildpackGUIDError{}
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.DeleteBuildpackRequest,
+		URIParams:   Params{"buildpack_guid": guid},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response cloudcontroller.Response
+	err = client.makeBuildpackWrite(request, &response)
+	return response.Warnings, buildpackWriteError(err, response)
+}
+
+// FindDuplicateBuildpackNames returns the buildpacks whose name is used by
+// more than one buildpack on the same stack, keyed by name. Names are compared
+// after Unicode normalization. A name used once on each of several stacks is
+// not a duplicate.
+func (client *Client) FindDuplicateBuildpackNames() (map[string][]Buildpack, Warnings, error) {
+	buildpacks, warnings, err := client.GetBuildpacks()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	byNameAndStack := map[string][]Buildpack{}
+	for _, buildpack := range buildpacks {
+		key := norm.NFC.String(buildpack.Name) + "@" + buildpack.Stack
+		byNameAndStack[key] = append(byNameAndStack[key], buildpack)
+	}
+
+	duplicates := map[string][]Buildpack{}
+	for _, buildpack := range buildpacks {
+		name := norm.NFC.String(buildpack.Name)
+		if len(byNameAndStack[name+"@"+buildpack.Stack]) > 1 {
+			duplicates[name] = append(duplicates[name], buildpack)
+		}
+	}
+
+	return duplicates, warnings, nil
+}
+
+// GetBuildpack returns the buildpack with the provided GUID.
+func (client *Client) GetBuildpack(guid string) (Buildpack, Warnings, error) {
+	if guid == "" {
+		return Buildpack{}, nil, ccerror.EmptyBuildpackGUIDError{}
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.GetBuildpackRequest,
+		URIParams:   Params{"buildpack_guid": guid},
+	})
+	if err != nil {
+		return Buildpack{}, nil, err
+	}
+
+	var buildpack Buildpack
+	response := cloudcontroller.Response{
+		Result:      &buildpack,
+		MaxBodySize: client.maxBuildpackResponseSize,
+	}
+
+	err = client.connection.Make(request, &response)
+	return buildpack, response.Warnings, err
+}
+
+// GetBuildpackByNameAndStack returns the buildpack with the provided name and
+// stack. An empty stack matches only buildpacks without a stack. Names are
+// compared in Unicode normalization form C, so names that differ only in
+// their normalization form match. If no buildpack matches, a
+// ccerror.BuildpackNotFoundError is returned.
+func (client *Client) GetBuildpackByNameAndStack(name string, stack string) (Buildpack, Warnings, error) {
+	nameFilter := Filter{
+		Type:     constant.NameFilter,
+		Operator: constant.EqualOperator,
+		Values:   []string{norm.NFC.String(name)},
+	}
+	// The Cloud Controller compares names byte for byte, so search for both
+	// normalization forms when they differ.
+	if decomposed := norm.NFD.String(name); decomposed != nameFilter.Values[0] {
+		nameFilter.Operator = constant.InOperator
+		nameFilter.Values = append(nameFilter.Values, decomposed)
+	}
+
+	filters := []Filter{nameFilter}
+	if stack != "" {
+		filters = append(filters, Filter{
+			Type:     constant.StackFilter,
+			Operator: constant.EqualOperator,
+			Values:   []string{stack},
+		})
+	}
+
+	buildpacks, warnings, err := client.GetBuildpacks(filters...)
+	if err != nil {
+		return Buildpack{}, warnings, err
+	}
+
+	for _, buildpack := range buildpacks {
+		if buildpackNamesMatch(buildpack.Name, name) && buildpack.Stack == stack {
+			return buildpack, warnings, nil
+		}
+	}
+
+	return Buildpack{}, warnings, ccerror.BuildpackNotFoundError{Name: name, Stack: stack}
+}
+
+// GetBuildpackByPosition returns the buildpack occupying the given position
+// on the given stack. An empty stack matches buildpacks without a stack. A
+// ccerror.DuplicatePositionError is returned when more than one buildpack
+// occupies the position.
+func (client *Client) GetBuildpackByPosition(position int, stack string) (Buildpack, Warnings, error) {
+	var filters []Filter
+	if stack != "" {
+		filters = append(filters, Filter{
+			Type:     constant.StackFilter,
+			Operator: constant.EqualOperator,
+			Values:   []string{stack},
+		})
+	}
+
+	buildpacks, warnings, err := client.GetBuildpacks(filters...)
+	if err != nil {
+		return Buildpack{}, warnings, err
+	}
+
+	var matches []Buildpack
+	for _, buildpack := range buildpacks {
+		if buildpack.Position == position && buildpack.Stack == stack {
+			matches = append(matches, buildpack)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Buildpack{}, warnings, ccerror.BuildpackNotFoundError{Stack: stack, Position: position}
+	case 1:
+		return matches[0], warnings, nil
+	default:
+		guids := make([]string, 0, len(matches))
+		for _, buildpack := range matches {
+			guids = append(guids, buildpack.GUID)
+		}
+		return Buildpack{}, warnings, ccerror.DuplicatePositionError{Position: position, Stack: stack, GUIDs: guids}
+	}
+}
+
+// GetBuildpackGUID returns the GUID of the buildpack with the provided name
+// and stack, matched the same way as GetBuildpackByNameAndStack. If no
+// buildpack matches, a ccerror.BuildpackNotFoundError is returned.
+func (client *Client) GetBuildpackGUID(name string, stack string) (string, Warnings, error) {
+	buildpack, warnings, err := client.GetBuildpackByNameAndStack(name, stack)
+	return buildpack.GUID, warnings, err
+}
+
+// GetBuildpacksByGUIDs returns the buildpacks with the provided GUIDs, in the
+// order of guids, for refreshing cached buildpacks. The V2 API cannot filter
+// buildpacks by GUID, so each buildpack is fetched individually rather than
+// listing every buildpack. Buildpacks that no longer exist are left out, and
+// duplicate GUIDs and warnings are returned once.
+func (client *Client) GetBuildpacksByGUIDs(guids []string) ([]Buildpack, Warnings, error) {
+	var (
+		buildpacks  []Buildpack
+		allWarnings Warnings
+	)
+	seenGUIDs := map[string]bool{}
+	seenWarnings := map[string]bool{}
+
+	for _, guid := range guids {
+		if seenGUIDs[guid] {
+			continue
+		}
+		seenGUIDs[guid] = true
+
+		buildpack, warnings, err := client.GetBuildpack(guid)
+		for _, warning := range warnings {
+			if !seenWarnings[warning] {
+				seenWarnings[warning] = true
+				allWarnings = append(allWarnings, warning)
+			}
+		}
+		if _, ok := err.(ccerror.ResourceNotFoundError); ok {
+			continue
+		}
+		if err != nil {
+			return nil, allWarnings, err
+		}
+
+		buildpacks = append(buildpacks, buildpack)
+	}
+
+	return buildpacks, allWarnings, nil
+}
+
+// GetBuildpacksByNamePrefix returns the buildpacks whose names start with
+// prefix, sorted by name and then stack. The V2 API has no prefix operator,
+// so every buildpack is listed and the names are matched locally.
+func (client *Client) GetBuildpacksByNamePrefix(prefix string) ([]Buildpack, Warnings, error) {
+	buildpacks, warnings, err := client.GetBuildpacks()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	var matches []Buildpack
+	for _, buildpack := range buildpacks {
+		if strings.HasPrefix(buildpack.Name, prefix) {
+			matches = append(matches, buildpack)
+		}
+	}
+
+	sort.SliceStable(matches, func(i int, j int) bool {
+		if matches[i].Name != matches[j].Name {
+			return matches[i].Name < matches[j].Name
+		}
+		return matches[i].Stack < matches[j].Stack
+	})
+
+	return matches, warnings, nil
+}
+
+// GetBuildpacksByNames returns the buildpacks with any of the provided names.
+// Names are queried with the IN operator in batches of at most
+// maxBuildpackNamesPerQuery. Buildpacks and warnings are deduplicated across
+// batches.
+func (client *Client) GetBuildpacksByNames(names []string) ([]Buildpack, Warnings, error) {
+	var (
+		buildpacks  []Buildpack
+		allWarnings Warnings
+	)
+	seenBuildpacks := map[string]bool{}
+	seenWarnings := map[string]bool{}
+
+	for start := 0; start < len(names); start += maxBuildpackNamesPerQuery {
+		end := start + maxBuildpackNamesPerQuery
+		if end > len(names) {
+			end = len(names)
+		}
+
+		batch, warnings, err := client.GetBuildpacks(Filter{
+			Type:     constant.NameFilter,
+			Operator: constant.InOperator,
+			Values:   names[start:end],
+		})
+		for _, warning := range warnings {
+			if !seenWarnings[warning] {
+				seenWarnings[warning] = true
+				allWarnings = append(allWarnings, warning)
+			}
+		}
+		if err != nil {
+			return nil, allWarnings, err
+		}
+
+		for _, buildpack := range batch {
+			if !seenBuildpacks[buildpack.GUID] {
+				seenBuildpacks[buildpack.GUID] = true
+				buildpacks = append(buildpacks, buildpack)
+			}
+		}
+	}
+
+	return buildpacks, allWarnings, nil
+}
+
+// GetBuildpacksOptions configures GetBuildpacksWithOptions.
+type GetBuildpacksOptions struct {
+	// Filters are applied to the buildpacks query. The Cloud Controller
+	// cannot filter buildpacks by creation time, so created_at filters, such
+	// as those returned by CreatedAfterFilter and CreatedBeforeFilter, are
+	// applied to the listed buildpacks instead.
+	Filters []Filter
+
+	// MaxPages is the maximum number of pages requested. If more pages
+	// remain, a ccerror.PaginationLimitError is returned along with the
+	// buildpacks already retrieved. If zero, all pages are requested.
+	MaxPages int
+
+	// Limit is the maximum number of buildpacks returned. No more pages are
+	// requested once it is reached, but the page size is unchanged, so use it
+	// to preview the first buildpacks of a listing. If zero, every matching
+	// buildpack is returned.
+	Limit int
+
+	// OnPageLinks, if set, is called with the pagination links of each page
+	// received. This helps diagnose pagination that ends early or loops.
+	OnPageLinks func(PaginationLinks)
+
+	// OrderBy is the field the Cloud Controller orders the buildpacks by. If
+	// empty, the Cloud Controller's default order is used.
+	OrderBy constant.OrderBy
+
+	// OrderDirection is the direction of the order. If empty, the Cloud
+	// Controller's default direction is used.
+	OrderDirection constant.OrderDirection
+
+	// DiscardWarnings skips collecting warnings from each page, and nil
+	// warnings are returned. Use it when warnings are never displayed.
+	DiscardWarnings bool
+
+	// OnWarning, if set, is called with each warning as soon as the page it
+	// was sent with is received, so that warnings can be shown before a long
+	// listing finishes. The warnings are still returned, unless
+	// DiscardWarnings is set.
+	OnWarning func(string)
+
+	// Stop, if set, halts the listing once it is closed. The buildpacks
+	// already retrieved are returned along with a ccerror.CancelledError.
+	Stop <-chan struct{}
+
+	// WarnOnPositionGaps adds a warning when the positions of the listed
+	// buildpacks skip a number, such as 1, 2, 5, which often means a reorder
+	// failed part way. Positions are compared across the listed buildpacks,
+	// so use it with listings of every buildpack. Like other warnings, it is
+	// passed to OnWarning and is not returned if DiscardWarnings is set.
+	WarnOnPositionGaps bool
+}
+
+// GetBuildpacks searches for a buildpack with the given name and returns it if it exists.
+func (client *Client) GetBuildpacks(filters ...Filter) ([]Buildpack, Warnings, error) {
+	return client.GetBuildpacksWithOptions(GetBuildpacksOptions{Filters: filters})
+}
+
+// GetBuildpacksWithStop behaves like GetBuildpacks, but stops requesting
+// pages once stop is closed. The buildpacks already retrieved are returned
+// along with a ccerror.CancelledError.
+func (client *Client) GetBuildpacksWithStop(stop <-chan struct{}, filters ...Filter) ([]Buildpack, Warnings, error) {
+	return client.GetBuildpacksWithOptions(GetBuildpacksOptions{Filters: filters, Stop: stop})
+}
+
+// GetBuildpacksWithOptions returns the buildpacks matching the provided
+// options.
+//
+// The Cloud Controller does not keep deleted buildpacks, so they are never
+// listed. Deletions can be audited through GetBuildpackEvents.
+func (client *Client) GetBuildpacksWithOptions(options GetBuildpacksOptions) ([]Buildpack, Warnings, error) {
+	queryFilters, createdAtFilters := splitCreatedAtFilters(client.transformBuildpackFilters(options.Filters))
+	createdAtMatcher, err := newCreatedAtMatcher(createdAtFilters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := ConvertFilterParameters(queryFilters)
+	if options.OrderBy != "" {
+		query.Set("order-by", string(options.OrderBy))
+	}
+	if options.OrderDirection != "" {
+		query.Set("order-direction", string(options.OrderDirection))
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.GetBuildpacksRequest,
+		Query:       query,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pageOptions := paginateOptions{
+		maxPages:        options.MaxPages,
+		onPageLinks:     options.OnPageLinks,
+		discardWarnings: options.DiscardWarnings,
+		onWarning:       options.OnWarning,
+		maxResponseSize: client.maxBuildpackResponseSize,
+		stop:            options.Stop,
+		cache:           client.buildpackListCache,
+	}
+
+	var buildpacks []Buildpack
+	warnings, err := client.paginateWithOptions(request, Buildpack{}, pageOptions, func(item interface{}) error {
+		if buildpack, ok := item.(Buildpack); ok {
+			if createdAtMatcher(buildpack.CreatedAt) {
+				buildpacks = append(buildpacks, buildpack)
+				if len(buildpacks) == options.Limit {
+					return errStopPagination
+				}
+			}
+		} else {
+			return ccerror.UnknownObjectInListError{
+				Expected:   Buildpack{},
+				Unexpected: item,
+			}
+		}
+		return nil
+	})
+
+	if err == nil && options.WarnOnPositionGaps {
+		if missing := missingBuildpackPositions(buildpacks); len(missing) > 0 {
+			warnings = collectPageWarnings(warnings, Warnings{positionGapsWarning(missing)}, pageOptions)
+		}
+	}
+
+	return buildpacks, warnings, err
+}
+
+// missingBuildpackPositions returns the positions between 1 and the highest
+// position of the buildpacks that no buildpack has.
+func missingBuildpackPositions(buildpacks []Buildpack) []int {
+	occupied := map[int]bool{}
+	highest := 0
+	for _, buildpack := range buildpacks {
+		occupied[buildpack.Position] = true
+		if buildpack.Position > highest {
+			highest = buildpack.Position
+		}
+	}
+
+	var missing []int
+	for position := 1; position < highest; position++ {
+		if !occupied[position] {
+			missing = append(missing, position)
+		}
+	}
+	return missing
+}
+
+// positionGapsWarning returns the warning WarnOnPositionGaps adds for the
+// missing positions.
+func positionGapsWarning(missing []int) string {
+	positions := make([]string, 0, len(missing))
+	for _, position := range missing {
+		positions = append(positions, strconv.Itoa(position))
+	}
+	return fmt.Sprintf("No buildpack has position %s. A previous reorder may have failed.", strings.Join(positions, ", "))
+}
+
+// GetBuildpacksMap returns the buildpacks matching the provided filters keyed
+// by name. When multiple buildpacks share a name (because they are on
+// different stacks), each of them is keyed by "name@stack" instead, and the
+// bare name is not present in the map.
+func (client *Client) GetBuildpacksMap(filters ...Filter) (map[string]Buildpack, Warnings, error) {
+	buildpacks, warnings, err := client.GetBuildpacks(filters...)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	nameCounts := map[string]int{}
+	for _, buildpack := range buildpacks {
+		nameCounts[buildpack.Name]++
+	}
+
+	buildpacksMap := map[string]Buildpack{}
+	for _, buildpack := range buildpacks {
+		if nameCounts[buildpack.Name] > 1 {
+			buildpacksMap[buildpackKey(buildpack)] = buildpack
+		} else {
+			buildpacksMap[buildpack.Name] = buildpack
+		}
+	}
+
+	return buildpacksMap, warnings, nil
+}
+
+// GetRecentBuildpacks returns up to limit buildpacks, most recently created
+// first. The Cloud Controller orders the buildpacks by CreatedAt, and only as
+// many pages as are needed to reach limit are requested. If limit is zero or
+// less, no buildpacks are returned.
+func (client *Client) GetRecentBuildpacks(limit int) ([]Buildpack, Warnings, error) {
+	if limit <= 0 {
+		return nil, nil, nil
+	}
+
+	resultsPerPage := limit
+	if resultsPerPage > maxResultsPerPage {
+		resultsPerPage = maxResultsPerPage
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.GetBuildpacksRequest,
+		Query: url.Values{
+			"order-by":         {string(constant.OrderByCreatedAt)},
+			"order-direction":  {string(constant.DescendingOrder)},
+			"results-per-page": {strconv.Itoa(resultsPerPage)},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pageOptions := paginateOptions{
+		maxResponseSize: client.maxBuildpackResponseSize,
+	}
+
+	var buildpacks []Buildpack
+	warnings, err := client.paginateWithOptions(request, Buildpack{}, pageOptions, func(item interface{}) error {
+		if buildpack, ok := item.(Buildpack); ok {
+			buildpacks = append(buildpacks, buildpack)
+			if len(buildpacks) == limit {
+				return errStopPagination
+			}
+		} else {
+			return ccerror.UnknownObjectInListError{
+				Expected:   Buildpack{},
+				Unexpected: item,
+			}
+		}
+		return nil
+	})
+
+	return buildpacks, warnings, err
+}
+
+// HeadBuildpackBits checks whether bits have been uploaded for the buildpack
+// with the provided GUID without downloading them. When the bits exist, their
+// size is returned. A missing buildpack or missing bits is not an error.
+func (client *Client) HeadBuildpackBits(guid string) (bool, int64, Warnings, error) {
+	if guid == "" {
+		return false, 0, nil, ccerror.EmptyBuildpackGUIDError{}
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.HeadBuildpackDownloadRequest,
+		URIParams:   Params{"buildpack_guid": guid},
+	})
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	response := cloudcontroller.Response{}
+
+	err = client.connection.Make(request, &response)
+	switch e := err.(type) {
+	case nil:
+		return true, response.HTTPResponse.ContentLength, response.Warnings, nil
+	case ccerror.ResourceNotFoundError:
+		return false, 0, response.Warnings, nil
+	case ccerror.UnknownHTTPSourceError:
+		// HEAD responses have no body, so 404s cannot be parsed into a CC
+		// error.
+		if e.StatusCode == http.StatusNotFound {
+			return false, 0, response.Warnings, nil
+		}
+	}
+
+	return false, 0, response.Warnings, err
+}
+
+// MustGetBuildpacks behaves like GetBuildpacks, but returns a
+// ccerror.BuildpackNotFoundError when no buildpack matches the filters. The
+// error's Name and Stack are set from single-value name and stack equality
+// filters.
+func (client *Client) MustGetBuildpacks(filters ...Filter) ([]Buildpack, Warnings, error) {
+	buildpacks, warnings, err := client.GetBuildpacks(filters...)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	if len(buildpacks) == 0 {
+		notFoundErr := ccerror.BuildpackNotFoundError{}
+		for _, filter := range filters {
+			if filter.Operator != constant.EqualOperator || len(filter.Values) != 1 {
+				continue
+			}
+			switch filter.Type {
+			case constant.NameFilter:
+				notFoundErr.Name = filter.Values[0]
+			case constant.StackFilter:
+				notFoundErr.Stack = filter.Values[0]
+			}
+		}
+		return nil, warnings, notFoundErr
+	}
+
+	return buildpacks, warnings, nil
+}
+
+// PingBuildpacksEndpoint checks that the buildpacks endpoint is reachable and
+// that the client is authorized to use it, by requesting a single buildpack.
+// Failures are returned as a ccerror.PingError classifying the cause.
+func (client *Client) PingBuildpacksEndpoint() (Warnings, error) {
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.GetBuildpacksRequest,
+		Query:       url.Values{"results-per-page": {"1"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response cloudcontroller.Response
+	err = client.connection.Make(request, &response)
+	if err != nil {
+		return response.Warnings, ccerror.PingError{
+			Reason: classifyPingError(err),
+			Err:    err,
+		}
+	}
+
+	return response.Warnings, nil
+}
+
+// RenameBuildpack changes the name of the buildpack with the provided GUID.
+// Only the name is sent, so no other settings are overwritten. If another
+// buildpack already has the new name and the same stack, a
+// ccerror.BuildpackAlreadyExistsError is returned.
+func (client *Client) RenameBuildpack(guid string, newName string) (Buildpack, Warnings, error) {
+	if guid == "" {
+		return Buildpack{}, nil, ccerror.EmptyBuildpackGUIDError{}
+	}
+
+	body, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{
+		Name: newName,
+	})
+	if err != nil {
+		return Buildpack{}, nil, err
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.PutBuildpackRequest,
+		URIParams:   Params{"buildpack_guid": guid},
+		Body:        bytes.NewReader(body),
+	})
+	if err != nil {
+		return Buildpack{}, nil, err
+	}
+
+	var updatedBuildpack Buildpack
+	response := cloudcontroller.Response{
+		Result:      &updatedBuildpack,
+		MaxBodySize: client.maxBuildpackResponseSize,
+	}
+
+	err = client.makeBuildpackWrite(request, &response)
+	switch e := err.(type) {
+	case nil:
+		return updatedBuildpack, response.Warnings, nil
+	case ccerror.BuildpackNameTakenError:
+		return Buildpack{}, response.Warnings, ccerror.BuildpackAlreadyExistsError{Message: e.Message}
+	case ccerror.BuildpackAlreadyExistsForStackError:
+		return Buildpack{}, response.Warnings, ccerror.BuildpackAlreadyExistsError{Message: e.Message}
+	default:
+		return Buildpack{}, response.Warnings, buildpackWriteError(err, response)
+	}
+}
+
+// RetryBuildpackUpload uploads the zip at buildpackPath to the existing
+// buildpack with the provided name and stack, for recovering when a buildpack
+// was created but uploading its bits failed. If no buildpack matches, a
+// ccerror.BuildpackNotFoundError is returned and nothing is uploaded.
+func (client *Client) RetryBuildpackUpload(name string, stack string, buildpackPath string) (Warnings, error) {
+	file, err := os.Open(buildpackPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	buildpack, allWarnings, err := client.GetBuildpackByNameAndStack(name, stack)
+	if err != nil {
+		return allWarnings, err
+	}
+
+	warnings, err := client.UploadBuildpack(buildpack.GUID, buildpackPath, file, info.Size())
+	return append(allWarnings, warnings...), err
+}
+
+// BulkReorderBuildpacks moves the buildpacks with the given GUIDs to the
+// first positions, in the order given. Other buildpacks follow in their
+// current relative order. A ccerror.ResourceNotFoundError is returned before
+// any change is made if a GUID has no buildpack.
+//
+// No version of the V2 API has an endpoint that reorders buildpacks in one
+// request, so each buildpack that is out of place is updated separately, and
+// concurrent changes to positions can interleave with the reorder.
+func (client *Client) BulkReorderBuildpacks(orderedGUIDs []string) (Warnings, error) {
+	all, allWarnings, err := client.GetBuildpacks()
+	if err != nil {
+		return allWarnings, err
+	}
+	sort.SliceStable(all, func(i int, j int) bool {
+		return all[i].Position < all[j].Position
+	})
+
+	existing := map[string]Buildpack{}
+	for _, buildpack := range all {
+		existing[buildpack.GUID] = buildpack
+	}
+
+	var desired []Buildpack
+	seen := map[string]bool{}
+	for _, guid := range orderedGUIDs {
+		buildpack, found := existing[guid]
+		if !found {
+			return allWarnings, ccerror.ResourceNotFoundError{
+				Message: fmt.Sprintf("The buildpack could not be found: %s", guid),
+			}
+		}
+		if !seen[guid] {
+			desired = append(desired, buildpack)
+			seen[guid] = true
+		}
+	}
+
+	for i, buildpack := range desired {
+		if all[i].GUID == buildpack.GUID {
+			continue
+		}
+
+		moved := buildpack
+		moved.Position = all[i].Position
+		_, warnings, err := client.UpdateBuildpack(moved)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			return allWarnings, err
+		}
+
+		all = moveBuildpack(all, buildpack.GUID, moved.Position)
+	}
+
+	return allWarnings, nil
+}
+
+// SetBuildpackOrder reorders the buildpacks on the given stack so the named
+// buildpacks come first, in the order given. Buildpacks on the stack that are
+// not named follow in their current relative order. Only buildpacks that are
+// out of place are updated. A ccerror.UnknownBuildpackNamesError listing
+// every name without a buildpack on the stack is returned before any change
+// is made.
+func (client *Client) SetBuildpackOrder(orderedNames []string, stack string) (Warnings, error) {
+	all, allWarnings, err := client.GetBuildpacks()
+	if err != nil {
+		return allWarnings, err
+	}
+	sort.SliceStable(all, func(i int, j int) bool {
+		return all[i].Position < all[j].Position
+	})
+
+	onStack := buildpacksOnStack(all, stack)
+
+	var (
+		desired      []Buildpack
+		unknownNames []string
+	)
+	named := map[string]bool{}
+	for _, name := range orderedNames {
+		found := false
+		for _, buildpack := range onStack {
+			if buildpackNamesMatch(buildpack.Name, name) {
+				if !named[buildpack.GUID] {
+					desired = append(desired, buildpack)
+					named[buildpack.GUID] = true
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknownNames = append(unknownNames, name)
+		}
+	}
+	if len(unknownNames) > 0 {
+		return allWarnings, ccerror.UnknownBuildpackNamesError{Names: unknownNames, Stack: stack}
+	}
+
+	for _, buildpack := range onStack {
+		if !named[buildpack.GUID] {
+			desired = append(desired, buildpack)
+		}
+	}
+
+	// The Cloud Controller keeps positions contiguous and shifts the
+	// buildpacks at and below a new position down by one, so the order is
+	// tracked locally to decide which moves are needed.
+	for i, buildpack := range desired {
+		current := buildpacksOnStack(all, stack)
+		if current[i].GUID == buildpack.GUID {
+			continue
+		}
+
+		moved := buildpack
+		moved.Position = current[i].Position
+		_, warnings, err := client.UpdateBuildpack(moved)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			return allWarnings, err
+		}
+
+		all = moveBuildpack(all, buildpack.GUID, moved.Position)
+	}
+
+	return allWarnings, nil
+}
+
+// StreamBuildpacks sends the buildpacks matching the provided filters on the
+// returned buildpack channel as each page arrives, so processing can start
+// before the whole list is retrieved. Once the buildpack channel is closed,
+// the error channel yields the error that ended the listing, if any, and is
+// then closed. Warnings are not reported. The buildpack channel must be
+// drained, or the listing goroutine will block.
+func (client *Client) StreamBuildpacks(filters ...Filter) (<-chan Buildpack, <-chan error) {
+	buildpacks := make(chan Buildpack)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(buildpacks)
+
+		queryFilters, createdAtFilters := splitCreatedAtFilters(client.transformBuildpackFilters(filters))
+		createdAtMatcher, err := newCreatedAtMatcher(createdAtFilters)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		request, err := client.newHTTPRequest(requestOptions{
+			RequestName: internal.GetBuildpacksRequest,
+			Query:       ConvertFilterParameters(queryFilters),
+		})
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		_, err = client.paginateWithOptions(request, Buildpack{}, paginateOptions{discardWarnings: true, maxResponseSize: client.maxBuildpackResponseSize}, func(item interface{}) error {
+			buildpack, ok := item.(Buildpack)
+			if !ok {
+				return ccerror.UnknownObjectInListError{
+					Expected:   Buildpack{},
+					Unexpected: item,
+				}
+			}
+			if createdAtMatcher(buildpack.CreatedAt) {
+				buildpacks <- buildpack
+			}
+			return nil
+		})
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return buildpacks, errs
+}
+
+// UpdateBuildpack updates the buildpack with the provided GUID and returns the updated buildpack.
+//
+// Changing the position cascades: the Cloud Controller shifts every buildpack
+// between the old and new positions by one to keep positions contiguous. The
+// V2 API has no way to set a position without this, so use
+// PreviewBuildpackReorder to see which buildpacks would move.
+func (client *Client) UpdateBuildpack(buildpack Buildpack) (Buildpack, Warnings, error) {
+	if buildpack.GUID == "" {
+		return Buildpack{}, nil, ccerror.EmptyBuildpackGUIDError{}
+	}
+
+	if client.validateBuildpacks {
+		err := client.validateBuildpack(buildpack)
+		if err != nil {
+			return Buildpack{}, nil, err
+		}
+	}
+
+	body, err := buildpack.ToUpdateBody()
+	if err != nil {
+		return Buildpack{}, nil, err
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.PutBuildpackRequest,
+		URIParams:   Params{"buildpack_guid": buildpack.GUID},
+		Body:        bytes.NewReader(body),
+	})
+	if err != nil {
+		return Buildpack{}, nil, err
+	}
+
+	var updatedBuildpack Buildpack
+	response := cloudcontroller.Response{
+		Result:      &updatedBuildpack,
+		MaxBodySize: client.maxBuildpackResponseSize,
+	}
+
+	err = client.makeBuildpackWrite(request, &response)
+	if err != nil {
+		return Buildpack{}, response.Warnings, buildpackWriteError(err, response)
+	}
+
+	return updatedBuildpack, response.Warnings, nil
+}
+
+// UpdateBuildpackIfUnmodified behaves like UpdateBuildpack, but first reads
+// the buildpack again and returns a ccerror.ConcurrentModificationError
+// without updating it if its UpdatedAt is not expectedUpdatedAt, the
+// UpdatedAt of the buildpack the caller read. Pass a zero time for a
+// buildpack that was never updated.
+//
+// The V2 API has no conditional update, so a change made between the read
+// and the update is still overwritten; the check only narrows that window.
+func (client *Client) UpdateBuildpackIfUnmodified(buildpack Buildpack, expectedUpdatedAt time.Time) (Buildpack, Warnings, error) {
+	current, allWarnings, err := client.GetBuildpack(buildpack.GUID)
+	if err != nil {
+		return Buildpack{}, allWarnings, err
+	}
+
+	if !current.UpdatedAt.Equal(expectedUpdatedAt) {
+		return Buildpack{}, allWarnings, ccerror.ConcurrentModificationError{
+			GUID:              buildpack.GUID,
+			ExpectedUpdatedAt: expectedUpdatedAt,
+			ActualUpdatedAt:   current.UpdatedAt,
+		}
+	}
+
+	updated, warnings, err := client.UpdateBuildpack(buildpack)
+	return updated, append(allWarnings, warnings...), err
+}
+
+// UpsertBuildpack ensures a buildpack with the name and stack of the provided
+// buildpack exists with its settings. The buildpack is created if it does not
+// exist, and otherwise updated only if its enabled state, locked state, or
+// position differ.
+// A Position of 0 keeps the current position. The returned bool is true if
+// the buildpack was created.
+func (client *Client) UpsertBuildpack(buildpack Buildpack) (Buildpack, bool, Warnings, error) {
+	existing, allWarnings, err := client.GetBuildpackByNameAndStack(buildpack.Name, buildpack.Stack)
+	switch err.(type) {
+	case nil:
+	case ccerror.BuildpackNotFoundError:
+		created, warnings, createErr := client.CreateBuildpack(buildpack)
+		allWarnings = append(allWarnings, warnings...)
+		if createErr != nil {
+			return Buildpack{}, false, allWarnings, createErr
+		}
+		return created, true, allWarnings, nil
+	default:
+		return Buildpack{}, false, allWarnings, err
+	}
+
+	if !buildpackNeedsUpdate(existing, buildpack) {
+		return existing, false, allWarnings, nil
+	}
+
+	updated, warnings, err := client.UpdateBuildpack(applyBuildpackChanges(existing, buildpack))
+	allWarnings = append(allWarnings, warnings...)
+	if err != nil {
+		return Buildpack{}, false, allWarnings, err
+	}
+	return updated, false, allWarnings, nil
+}
+
+// ValidateBuildpackForFoundation checks the buildpack for problems that would
+// stop it from being created or used on the targeted Cloud Controller without
+// creating it, so that a whole set of buildpacks can be checked first. Along
+// with the checks made by Buildpack.Validate, the buildpack's stack must be
+// one of the foundation's stacks, and must be set if the Cloud Controller
+// requires it. All problems found are returned in a
+// ccerror.BuildpackValidationError.
+func (client *Client) ValidateBuildpackForFoundation(buildpack Buildpack) (Warnings, error) {
+	problems := client.buildpackValidationProblems(buildpack)
+
+	var warnings Warnings
+	if buildpack.Stack != "" {
+		stacks, stackWarnings, err := client.GetStacks()
+		warnings = stackWarnings
+		if err != nil {
+			return warnings, err
+		}
+
+		if problem := missingStackProblem(buildpack.Stack, stacks); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	if len(problems) > 0 {
+		return warnings, ccerror.BuildpackValidationError{Problems: problems}
+	}
+	return warnings, nil
+}
+
+// UploadBuildpack uploads the contents of a buildpack zip to the server. The
+// Cloud Controller processes the bits before responding, so no job is
+// returned and there is nothing to poll once UploadBuildpack returns.
+//
+// If buildpackLength is -1 the length of the buildpack is treated as unknown
+// and the request is sent using chunked transfer encoding. This allows
+// streaming from readers such as os.Stdin, but requires that the Cloud
+// Controller and any proxies in front of it accept chunked uploads.
+//
+// Some Cloud Controllers respond with a 409 Conflict when bits are uploaded
+// immediately after the buildpack is created. If buildpack is an io.Seeker,
+// the upload is retried from the start of the reader a bounded number of times
+// (see Config.BuildpackBitsNotReadyRetries). A
+// ccerror.BuildpackBitsNotReadyError is returned if the conflict persists.
+//
+// A ccerror.BuildpackTooLargeError is returned if the Cloud Controller rejects
+// the buildpack for exceeding its maximum size.
+//
+// If Config.RequiredBuildpackFiles is set, a ccerror.IncompleteBuildpackError
+// is returned without uploading anything when the zip is missing any of them.
+//
+// If Config.VerifyBuildpackArchives is set, a
+// ccerror.CorruptBuildpackArchiveError is returned without uploading anything
+// when an entry of the zip does not match its checksum. Use
+// WithoutBuildpackArchiveCheck to skip the check for a single upload.
+//
+// If Config.CheckBuildpackLock is set, a ccerror.BuildpackLockedError is
+// returned without uploading anything when the buildpack is locked. Use
+// WithoutBuildpackLockCheck to skip the check for a single upload.
+//
+// The V2 API has no endpoint for deleting a buildpack's bits while keeping the
+// buildpack, so stale bits can only be replaced by uploading new ones.
+func (client *Client) UploadBuildpack(buildpackGUID string, buildpackPath string, buildpack io.Reader, buildpackLength int64) (Warnings, error) {
+	return client.UploadBuildpackWithMetadata(buildpackGUID, buildpackPath, buildpack, buildpackLength, nil)
+}
+
+// SizedReader is a reader that knows its own size, such as a *bytes.Reader,
+// a *strings.Reader, or an *io.SectionReader of a file.
+type SizedReader interface {
+	io.Reader
+	Size() int64
+}
+
+// UploadBuildpackFromSizer behaves like UploadBuildpack, taking the length of
+// the buildpack from the reader so that the two cannot disagree. The reader
+// must be at its start, since Size reports its whole size.
+func (client *Client) UploadBuildpackFromSizer(buildpackGUID string, buildpackPath string, buildpack SizedReader) (Warnings, error) {
+	return client.UploadBuildpack(buildpackGUID, buildpackPath, buildpack, buildpack.Size())
+}
+
+// UploadBuildpackWithMetadata behaves like UploadBuildpack, but also sends
+// metadata as a "metadata" form field in the same multipart request. No
+// metadata field is sent if metadata is nil.
+func (client *Client) UploadBuildpackWithMetadata(buildpackGUID string, buildpackPath string, buildpack io.Reader, buildpackLength int64, metadata json.RawMessage) (Warnings, error) {
+	if buildpackGUID == "" {
+		return nil, ccerror.EmptyBuildpackGUIDError{}
+	}
+
+	err := client.checkBuildpackArchive(buildpack, buildpackLength)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.checkRequiredBuildpackFiles(buildpack, buildpackLength)
+	if err != nil {
+		return nil, err
+	}
+
+	allWarnings, err := client.checkBuildpackUnlocked(buildpackGUID)
+	if err != nil {
+		return allWarnings, err
+	}
+
+	warnings, err := client.uploadBuildpackWithRetries(buildpackGUID, buildpack, buildpackLength, func() (Warnings, error) {
+		return client.uploadBuildpackBits(buildpackGUID, buildpackPath, buildpack, buildpackLength, metadata)
+	})
+	return append(allWarnings, warnings...), err
+}
+
+// UploadPreparedBuildpack uploads a body created by PrepareBuildpackUpload.
+// It behaves like UploadBuildpack; since the body is a file, retries always
+// restart from its beginning.
+func (client *Client) UploadPreparedBuildpack(buildpackGUID string, prepared PreparedBuildpackUpload) (Warnings, error) {
+	if buildpackGUID == "" {
+		return nil, ccerror.EmptyBuildpackGUIDError{}
+	}
+
+	allWarnings, err := client.checkBuildpackUnlocked(buildpackGUID)
 	if err != nil {
-		return err
+		return allWarnings, err
 	}
 
-	buildpack.Enabled = alias.Entity.Enabled
-	buildpack.GUID = alias.Metadata.GUID
-	buildpack.Name = alias.Entity.Name
-	buildpack.Position = alias.Entity.Position
+	// A section reader does not implement io.Closer, so the HTTP client cannot
+	// close the file after the first attempt.
+	body := io.NewSectionReader(prepared.body, 0, prepared.ContentLength)
 
-	return nil
+	warnings, err := client.uploadBuildpackWithRetries(buildpackGUID, body, prepared.BuildpackSize, func() (Warnings, error) {
+		request, err := client.newHTTPRequest(requestOptions{
+			RequestName: internal.PutBuildpackBitsRequest,
+			URIParams:   Params{"buildpack_guid": buildpackGUID},
+			Body:        client.withUploadProgress(body, prepared.ContentLength),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		request.Header.Set("Content-Type", prepared.ContentType)
+		request.ContentLength = prepared.ContentLength
+		client.setUploadExpectContinue(request)
+
+		response := cloudcontroller.Response{
+			MaxBodySize: client.maxBuildpackResponseSize,
+		}
+		err = client.makeBuildpackWrite(request, &response)
+		return response.Warnings, err
+	})
+	return append(allWarnings, warnings...), err
 }
 
-// CreateBuildpack creates a new buildpack.
-func (client *Client) CreateBuildpack(buildpack Buildpack) (Buildpack, Warnings, error) {
-	body, err := json.Marshal(buildpack)
-	if err != nil {
-		return Buildpack{}, nil, err
+// uploadBuildpackWithRetries calls upload, retrying from the start of
+// buildpack while the Cloud Controller reports that the buildpack is not
+// ready to receive bits, and converts size limit errors into
+// ccerror.BuildpackTooLargeError. The Cloud Controller's error is returned
+// unchanged when the upload cannot be retried, such as when buildpack is not
+// an io.Seeker.
+func (client *Client) uploadBuildpackWithRetries(buildpackGUID string, buildpack io.Reader, buildpackLength int64, upload func() (Warnings, error)) (Warnings, error) {
+	var allWarnings Warnings
+
+	for attempt := 1; ; attempt++ {
+		warnings, err := upload()
+		allWarnings = append(allWarnings, warnings...)
+		if limit, tooLarge := buildpackTooLarge(err); tooLarge {
+			return allWarnings, ccerror.BuildpackTooLargeError{
+				Size:  buildpackLength,
+				Limit: limit,
+			}
+		}
+		if !isBuildpackBitsNotReadyError(err) {
+			return allWarnings, err
+		}
+
+		seeker, isSeeker := buildpack.(io.Seeker)
+		if attempt == 1 && (!isSeeker || client.buildpackBitsNotReadyRetries < 1) {
+			return allWarnings, err
+		}
+		if !isSeeker || attempt > client.buildpackBitsNotReadyRetries {
+			return allWarnings, ccerror.BuildpackBitsNotReadyError{
+				BuildpackGUID: buildpackGUID,
+				Attempts:      attempt,
+			}
+		}
+
+		_, err = seeker.Seek(0, io.SeekStart)
+		if err != nil {
+			return allWarnings, err
+		}
+
+		time.Sleep(client.buildpackBitsNotReadyRetryInterval)
+	}
+}
+
+func (client *Client) uploadBuildpackBits(buildpackGUID string, buildpackPath string, buildpack io.Reader, buildpackLength int64, metadata json.RawMessage) (Warnings, error) {
+	contentLength := int64(-1)
+	if buildpackLength != -1 {
+		contentLength = client.calculateBuildpackRequestSize(buildpackLength, buildpackPath, metadata)
 	}
 
+	if client.uploadRateLimiter != nil {
+		buildpack = limitedReader{reader: buildpack, limiter: client.uploadRateLimiter}
+	}
+
+	// The request and the body writer share a context, so canceling the
+	// upload also stops reading the buildpack.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	contentType, body, bodyWriter, writeErrors := client.createMultipartBodyAndHeaderForBuildpack(ctx, buildpack, buildpackPath, metadata)
+
 	request, err := client.newHTTPRequest(requestOptions{
-		RequestName: internal.PostBuildpackRequest,
-		Body:        bytes.NewReader(body),
+		RequestName: internal.PutBuildpackBitsRequest,
+		URIParams:   Params{"buildpack_guid": buildpackGUID},
+		Body:        client.withUploadProgress(body, contentLength),
 	})
+
 	if err != nil {
-		return Buildpack{}, nil, err
+		return nil, err
 	}
-	var createdBuildpack Buildpack
-	response := cloudcontroller.Response{
-		Result: &createdBuildpack,
+
+	request.Request = request.Request.WithContext(ctx)
+	request.Header.Set("Content-Type", contentType)
+	request.ContentLength = contentLength
+	client.setUploadExpectContinue(request)
+
+	if client.uploadTrace != nil {
+		request.Request = request.Request.WithContext(httptrace.WithClientTrace(request.Context(), client.uploadTrace.clientTrace()))
 	}
 
-	err = client.connection.Make(request, &response)
-	return createdBuildpack, response.Warnings, err
+	_, warnings, err := client.uploadBuildpackAsynchronously(request, cancel, bodyWriter, writeErrors)
+	return warnings, err
 }
 
-// GetBuildpacks searches for a buildpack with the given name and returns it if it exists.
-func (client *Client) GetBuildpacks(filters ...Filter) ([]Buildpack, Warnings, error) {
+// setUploadExpectContinue makes an upload request wait for the Cloud
+// Controller to accept it before sending the bits, if the client is
+// configured to.
+func (client *Client) setUploadExpectContinue(request *cloudcontroller.Request) {
+	if client.uploadExpectContinueTimeout > 0 {
+		request.Header.Set("Expect", "100-continue")
+	}
+}
+
+// getMaxBuildpackPosition returns the highest position currently occupied by
+// a buildpack, or 0 when there are no buildpacks. Only the first page of a
+// single-item, position-descending list is requested.
+func (client *Client) getMaxBuildpackPosition() (int, Warnings, error) {
 	request, err := client.newHTTPRequest(requestOptions{
 		RequestName: internal.GetBuildpacksRequest,
-		Query:       ConvertFilterParameters(filters),
+		Query: url.Values{
+			"order-by":         {string(constant.OrderByPosition)},
+			"order-direction":  {string(constant.DescendingOrder)},
+			"results-per-page": {"1"},
+		},
 	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	page := NewPaginatedResources(Buildpack{})
+	response := cloudcontroller.Response{
+		Result:      page,
+		MaxBodySize: client.maxBuildpackResponseSize,
+	}
 
+	err = client.connection.Make(request, &response)
 	if err != nil {
-		return nil, nil, err
+		return 0, response.Warnings, err
 	}
 
-	var buildpacks []Buildpack
-	warnings, err := client.paginate(request, Buildpack{}, func(item interface{}) error {
-		if buildpack, ok := item.(Buildpack); ok {
-			buildpacks = append(buildpacks, buildpack)
+	list, err := page.Resources()
+	if err != nil {
+		return 0, response.Warnings, err
+	}
+
+	if len(list) == 0 {
+		return 0, response.Warnings, nil
+	}
+
+	return list[0].(Buildpack).Position, response.Warnings, nil
+}
+
+// buildpackNamesMatch returns true if the names are equal once both are in
+// Unicode normalization form C.
+func buildpackNamesMatch(name1 string, name2 string) bool {
+	return norm.NFC.String(name1) == norm.NFC.String(name2)
+}
+
+// classifyPingError determines the cause of a failed ping.
+func classifyPingError(err error) ccerror.PingFailureReason {
+	switch e := err.(type) {
+	case ccerror.UnauthorizedError, ccerror.InvalidAuthTokenError, ccerror.ForbiddenError:
+		return ccerror.PingFailureAuth
+	case ccerror.RequestError, ccerror.UnverifiedServerError, ccerror.SSLValidationHostnameError:
+		return ccerror.PingFailureConnectivity
+	case ccerror.V2UnexpectedResponseError:
+		if e.ResponseCode >= http.StatusInternalServerError {
+			return ccerror.PingFailureServer
+		}
+	}
+	return ccerror.PingFailureUnknown
+}
+
+// buildpackWriteError explains that a ccerror.ForbiddenError returned when
+// modifying a buildpack is due to lacking admin scope, and returns every
+// error of responses with an array of errors (see responseMultiError). Other
+// errors are returned unchanged.
+func buildpackWriteError(err error, response cloudcontroller.Response) error {
+	if multiErr, ok := responseMultiError(err, response); ok {
+		return multiErr
+	}
+
+	if e, ok := err.(ccerror.ForbiddenError); ok {
+		return ccerror.ForbiddenError{
+			Message: fmt.Sprintf("Managing buildpacks requires the cloud_controller.admin scope: %s", e.Message),
+		}
+	}
+	return err
+}
+
+// responseMultiError returns a ccerror.MultiError with every error of a
+// failed response whose body has an "errors" array, such as the validation
+// failures of some Cloud Controllers, instead of the single V2 error the
+// error wrapper parses.
+func responseMultiError(err error, response cloudcontroller.Response) (ccerror.MultiError, bool) {
+	if err == nil || response.HTTPResponse == nil || response.HTTPResponse.StatusCode < http.StatusBadRequest {
+		return ccerror.MultiError{}, false
+	}
+
+	var body struct {
+		Errors []ccerror.V3Error `json:"errors"`
+	}
+	if json.Unmarshal(response.RawResponse, &body) != nil || len(body.Errors) == 0 {
+		return ccerror.MultiError{}, false
+	}
+
+	return ccerror.MultiError{
+		Errors:       body.Errors,
+		ResponseCode: response.HTTPResponse.StatusCode,
+	}, true
+}
+
+// buildpacksOnStack returns the buildpacks with the given stack, keeping
+// their order.
+func buildpacksOnStack(buildpacks []Buildpack, stack string) []Buildpack {
+	var onStack []Buildpack
+	for _, buildpack := range buildpacks {
+		if buildpack.Stack == stack {
+			onStack = append(onStack, buildpack)
+		}
+	}
+	return onStack
+}
+
+// transformBuildpackFilters returns the filters of a buildpack listing after
+// applying the client's filter transformer, if it has one.
+func (client *Client) transformBuildpackFilters(filters []Filter) []Filter {
+	if client.filterTransformer == nil {
+		return filters
+	}
+	return client.filterTransformer(filters)
+}
+
+// splitCreatedAtFilters separates the created_at filters, which the Cloud
+// Controller does not support for buildpacks, from the other filters.
+func splitCreatedAtFilters(filters []Filter) ([]Filter, []Filter) {
+	var queryFilters, createdAtFilters []Filter
+	for _, filter := range filters {
+		if filter.Type == constant.CreatedAtFilter {
+			createdAtFilters = append(createdAtFilters, filter)
 		} else {
-			return ccerror.UnknownObjectInListError{
-				Expected:   Buildpack{},
-				Unexpected: item,
+			queryFilters = append(queryFilters, filter)
+		}
+	}
+	return queryFilters, createdAtFilters
+}
+
+// newCreatedAtMatcher returns a function that reports whether a creation time
+// satisfies all of the created_at filters. An IN filter is satisfied by any
+// of its values; the other operators must hold for every value. A
+// ccerror.UnsupportedFilterOperatorError is returned for any other operator.
+func newCreatedAtMatcher(filters []Filter) (func(time.Time) bool, error) {
+	type condition struct {
+		operator constant.FilterOperator
+		values   []time.Time
+	}
+
+	var conditions []condition
+	for _, filter := range filters {
+		switch filter.Operator {
+		case constant.EqualOperator, constant.GreaterThanOperator, constant.InOperator, constant.LessThanOperator:
+		default:
+			return nil, ccerror.UnsupportedFilterOperatorError{
+				FilterType: string(filter.Type),
+				Operator:   string(filter.Operator),
 			}
 		}
-		return nil
-	})
 
-	return buildpacks, warnings, err
+		c := condition{operator: filter.Operator}
+		for _, value := range filter.Values {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, err
+			}
+			c.values = append(c.values, parsed)
+		}
+		conditions = append(conditions, c)
+	}
+
+	matches := func(createdAt time.Time, operator constant.FilterOperator, value time.Time) bool {
+		switch operator {
+		case constant.GreaterThanOperator:
+			return createdAt.After(value)
+		case constant.LessThanOperator:
+			return createdAt.Before(value)
+		default:
+			return createdAt.Equal(value)
+		}
+	}
+
+	return func(createdAt time.Time) bool {
+		for _, c := range conditions {
+			if c.operator == constant.InOperator {
+				matched := false
+				for _, value := range c.values {
+					if matches(createdAt, c.operator, value) {
+						matched = true
+						break
+					}
+				}
+				if !matched {
+					return false
+				}
+				continue
+			}
+
+			for _, value := range c.values {
+				if !matches(createdAt, c.operator, value) {
+					return false
+				}
+			}
+		}
+		return true
+	}, nil
 }
 
-// UpdateBuildpack updates the buildpack with the provided GUID and returns the updated buildpack.
-func (client *Client) UpdateBuildpack(buildpack Buildpack) (Buildpack, Warnings, error) {
-	body, err := json.Marshal(buildpack)
-	if err != nil {
-		return Buildpack{}, nil, err
+// moveBuildpack returns the position-ordered buildpacks with the one with the
+// given GUID moved to position, renumbering every buildpack from 1. Positions
+// below 1 move it to the start and positions past the end move it to the end.
+func moveBuildpack(buildpacks []Buildpack, guid string, position int) []Buildpack {
+	var (
+		moved     Buildpack
+		remaining []Buildpack
+	)
+	for _, buildpack := range buildpacks {
+		if buildpack.GUID == guid {
+			moved = buildpack
+		} else {
+			remaining = append(remaining, buildpack)
+		}
 	}
 
-	request, err := client.newHTTPRequest(requestOptions{
-		RequestName: internal.PutBuildpackRequest,
-		URIParams:   Params{"buildpack_guid": buildpack.GUID},
-		Body:        bytes.NewReader(body),
-	})
-	if err != nil {
-		return Buildpack{}, nil, err
+	index := position - 1
+	if index < 0 {
+		index = 0
 	}
+	if index > len(remaining) {
+		index = len(remaining)
+	}
+	reordered := append([]Buildpack{}, remaining[:index]...)
+	reordered = append(reordered, moved)
+	reordered = append(reordered, remaining[index:]...)
 
-	var updatedBuildpack Buildpack
-	response := cloudcontroller.Response{
-		Result: &updatedBuildpack,
+	for i := range reordered {
+		reordered[i].Position = i + 1
 	}
+	return reordered
+}
 
-	err = client.connection.Make(request, &response)
+// buildpackTooLarge returns true if the error is a 413 Request Entity Too
+// Large, along with the size limit in bytes if the response included one.
+func buildpackTooLarge(err error) (int64, bool) {
+	switch e := err.(type) {
+	case ccerror.V2UnexpectedResponseError:
+		if e.ResponseCode == http.StatusRequestEntityTooLarge {
+			return parseBuildpackSizeLimit(e.Description), true
+		}
+	case ccerror.UnknownHTTPSourceError:
+		if e.StatusCode == http.StatusRequestEntityTooLarge {
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// parseBuildpackSizeLimit returns the size in bytes mentioned in message, or
+// 0 if there is none.
+func parseBuildpackSizeLimit(message string) int64 {
+	matches := buildpackSizeLimitRegexp.FindStringSubmatch(message)
+	if matches == nil {
+		return 0
+	}
+
+	size, err := strconv.ParseInt(matches[1], 10, 64)
 	if err != nil {
-		return Buildpack{}, response.Warnings, err
+		return 0
 	}
 
-	return updatedBuildpack, response.Warnings, nil
+	switch strings.ToLower(matches[2]) {
+	case "kb":
+		size *= 1024
+	case "mb":
+		size *= 1024 * 1024
+	case "gb":
+		size *= 1024 * 1024 * 1024
+	}
+	return size
 }
 
-// UploadBuildpack uploads the contents of a buildpack zip to the server.
-func (client *Client) UploadBuildpack(buildpackGUID string, buildpackPath string, buildpack io.Reader, buildpackLength int64) (Warnings, error) {
+// buildpackBitsNotReadyErrorCode is the error code of the 409 Conflict the
+// Cloud Controller returns when a buildpack is not ready to receive bits.
+// Other conflicts, such as name collisions, have their own codes.
+const buildpackBitsNotReadyErrorCode = "CF-Conflict"
 
-	contentLength, err := client.calculateBuildpackRequestSize(buildpackLength, buildpackPath)
-	if err != nil {
-		return nil, err
+// isBuildpackBitsNotReadyError returns true if the error is the 409 Conflict
+// the Cloud Controller returns when a buildpack is not ready to receive bits.
+func isBuildpackBitsNotReadyError(err error) bool {
+	if e, ok := err.(ccerror.V2UnexpectedResponseError); ok {
+		return e.ResponseCode == http.StatusConflict && e.ErrorCode == buildpackBitsNotReadyErrorCode
 	}
+	return false
+}
 
-	contentType, body, writeErrors := client.createMultipartBodyAndHeaderForBuildpack(buildpack, buildpackPath)
+// validateBuildpack runs Buildpack.Validate along with any checks that depend
+// on the targeted Cloud Controller's API version.
+func (client *Client) validateBuildpack(buildpack Buildpack) error {
+	problems := client.buildpackValidationProblems(buildpack)
+	if len(problems) > 0 {
+		return ccerror.BuildpackValidationError{Problems: problems}
+	}
+	return nil
+}
 
-	request, err := client.newHTTPRequest(requestOptions{
-		RequestName: internal.PutBuildpackBitsRequest,
-		URIParams:   Params{"buildpack_guid": buildpackGUID},
-		Body:        body,
-	})
+func (client *Client) buildpackValidationProblems(buildpack Buildpack) []string {
+	problems := buildpack.validationProblems()
 
-	if err != nil {
-		return nil, err
+	if buildpack.Stack == "" && cloudcontroller.MinimumAPIVersionCheck(client.APIVersion(), ccversion.MinVersionBuildpackStackRequiredV2) == nil {
+		problems = append(problems, "stack must not be empty")
 	}
 
-	request.Header.Set("Content-Type", contentType)
-	request.ContentLength = contentLength
+	return problems
+}
+
+// missingStackProblem returns a problem naming the available stacks if stack
+// is not one of them, and an empty string otherwise.
+func missingStackProblem(stack string, stacks []Stack) string {
+	names := make([]string, 0, len(stacks))
+	for _, available := range stacks {
+		if available.Name == stack {
+			return ""
+		}
+		names = append(names, available.Name)
+	}
+
+	if len(names) == 0 {
+		return fmt.Sprintf("stack '%s' does not exist and the foundation has no stacks", stack)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("stack '%s' does not exist; available stacks are '%s'", stack, strings.Join(names, "', '"))
+}
+
+// checkBuildpackUnlocked returns a ccerror.BuildpackLockedError if the
+// client checks buildpack locks and the buildpack is locked.
+func (client *Client) checkBuildpackUnlocked(guid string) (Warnings, error) {
+	if !client.checkBuildpackLock {
+		return nil, nil
+	}
 
-	_, warnings, err := client.uploadBuildpackAsynchronously(request, writeErrors)
+	buildpack, warnings, err := client.GetBuildpack(guid)
 	if err != nil {
 		return warnings, err
 	}
-	return warnings, nil
 
+	if buildpack.Locked {
+		return warnings, ccerror.BuildpackLockedError{Name: buildpack.Name}
+	}
+	return warnings, nil
 }
 
-func (*Client) calculateBuildpackRequestSize(buildpackSize int64, bpPath string) (int64, error) {
-	body := &bytes.Buffer{}
-	form := multipart.NewWriter(body)
+// checkBuildpackArchive returns a ccerror.CorruptBuildpackArchiveError if
+// the client verifies buildpack archives and the zip cannot be read or any of
+// its entries does not match its checksum. Like checkRequiredBuildpackFiles,
+// it returns a ccerror.UncheckableBuildpackError when the zip cannot be read
+// at an offset or its length is unknown.
+func (client *Client) checkBuildpackArchive(buildpack io.Reader, buildpackLength int64) error {
+	if !client.verifyBuildpackArchives {
+		return nil
+	}
 
-	bpFileName := filepath.Base(bpPath)
+	readerAt, ok := buildpack.(io.ReaderAt)
+	if !ok || buildpackLength < 0 {
+		return ccerror.UncheckableBuildpackError{Check: "corrupt entries"}
+	}
 
-	_, err := form.CreateFormFile("buildpack", bpFileName)
+	// Any error reading the zip's directory, not only zip.ErrFormat, means
+	// the zip cannot be uploaded as it is.
+	archive, err := zip.NewReader(readerAt, buildpackLength)
 	if err != nil {
-		return 0, err
+		return ccerror.CorruptBuildpackArchiveError{}
+	}
+
+	var corrupt []string
+	for _, file := range archive.File {
+		if !zipEntryIntact(file) {
+			corrupt = append(corrupt, file.Name)
+		}
+	}
+
+	if len(corrupt) > 0 {
+		return ccerror.CorruptBuildpackArchiveError{Entries: corrupt}
 	}
+	return nil
+}
 
-	err = form.Close()
+// zipEntryIntact reads the entry to the end, where archive/zip compares its
+// contents with the stored CRC-32 checksum.
+func zipEntryIntact(file *zip.File) bool {
+	contents, err := file.Open()
 	if err != nil {
-		return 0, err
+		return false
+	}
+	defer contents.Close()
+
+	_, err = io.Copy(ioutil.Discard, contents)
+	return err == nil
+}
+
+// checkRequiredBuildpackFiles returns a ccerror.IncompleteBuildpackError if
+// the buildpack zip is missing any of the client's required files. Only the
+// zip's central directory is read, so a ccerror.UncheckableBuildpackError is
+// returned when the zip cannot be read at an offset or its length is unknown.
+func (client *Client) checkRequiredBuildpackFiles(buildpack io.Reader, buildpackLength int64) error {
+	if len(client.requiredBuildpackFiles) == 0 {
+		return nil
+	}
+
+	readerAt, ok := buildpack.(io.ReaderAt)
+	if !ok || buildpackLength < 0 {
+		return ccerror.UncheckableBuildpackError{Check: "required files"}
+	}
+
+	archive, err := zip.NewReader(readerAt, buildpackLength)
+	if err != nil {
+		return err
+	}
+
+	present := map[string]bool{}
+	for _, file := range archive.File {
+		present[strings.TrimPrefix(file.Name, "./")] = true
+	}
+
+	var missing []string
+	for _, name := range client.requiredBuildpackFiles {
+		if !present[name] {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return ccerror.IncompleteBuildpackError{MissingFiles: missing}
+	}
+	return nil
+}
+
+// calculateBuildpackRequestSize returns the size of the body that
+// createMultipartBodyAndHeaderForBuildpack writes for a buildpack of
+// buildpackSize bytes. It adds up the lengths of the boundaries and part
+// headers rather than building the body.
+func (client *Client) calculateBuildpackRequestSize(buildpackSize int64, bpPath string, metadata json.RawMessage) int64 {
+	// Each part starts with a "--boundary\r\n" line, and every part after the
+	// first is separated from the previous one by "\r\n".
+	delimiterLength := int64(len("--") + multipartBoundaryLength + len("\r\n"))
+
+	var size int64
+	if metadata != nil {
+		size += delimiterLength + multipartHeaderLength(buildpackMetadataPartHeader()) + int64(len(metadata)) + int64(len("\r\n"))
 	}
+	size += delimiterLength + multipartHeaderLength(client.buildpackFilePartHeader(bpPath)) + buildpackSize
+	size += int64(len("\r\n--") + multipartBoundaryLength + len("--\r\n"))
+
+	return size
+}
 
-	return int64(body.Len()) + buildpackSize, nil
+// multipartHeaderLength returns the number of bytes multipart.Writer writes
+// for a part's header, including the blank line that ends it.
+func multipartHeaderLength(header textproto.MIMEHeader) int64 {
+	var length int
+	for key, values := range header {
+		for _, value := range values {
+			length += len(key) + len(": ") + len(value) + len("\r\n")
+		}
+	}
+	return int64(length + len("\r\n"))
 }
 
-func (*Client) createMultipartBodyAndHeaderForBuildpack(buildpack io.Reader, bpPath string) (string, io.ReadSeeker, <-chan error) {
+func (client *Client) createMultipartBodyAndHeaderForBuildpack(ctx context.Context, buildpack io.Reader, bpPath string, metadata json.RawMessage) (string, io.ReadSeeker, io.WriteCloser, <-chan error) {
 	writerOutput, writerInput := cloudcontroller.NewPipeBomb()
 
 	form := multipart.NewWriter(writerInput)
@@ -183,15 +2030,29 @@ func (*Client) createMultipartBodyAndHeaderForBuildpack(buildpack io.Reader, bpP
 	go func() {
 		defer close(writeErrors)
 		defer writerInput.Close()
+		defer func() {
+			if r := recover(); r != nil {
+				panicErr := ccerror.UploadPanicError{Value: r}
+				// Report the panic before failing the pipe so it is the first
+				// error seen by uploadBuildpackAsynchronously.
+				writeErrors <- panicErr
+				closeUploadPipe(writerInput, panicErr)
+			}
+		}()
+
+		err := writeBuildpackMetadataField(form, metadata)
+		if err != nil {
+			writeErrors <- err
+			return
+		}
 
-		bpFileName := filepath.Base(bpPath)
-		writer, err := form.CreateFormFile("buildpack", bpFileName)
+		writer, err := client.createBuildpackFormFile(form, bpPath)
 		if err != nil {
 			writeErrors <- err
 			return
 		}
 
-		_, err = io.Copy(writer, buildpack)
+		_, err = io.Copy(writer, contextReader{ctx: ctx, reader: buildpack})
 		if err != nil {
 			writeErrors <- err
 			return
@@ -203,14 +2064,93 @@ func (*Client) createMultipartBodyAndHeaderForBuildpack(buildpack io.Reader, bpP
 		}
 	}()
 
-	return form.FormDataContentType(), writerOutput, writeErrors
+	return form.FormDataContentType(), writerOutput, writerInput, writeErrors
 }
 
-func (client *Client) uploadBuildpackAsynchronously(request *cloudcontroller.Request, writeErrors <-chan error) (Buildpack, Warnings, error) {
+// contextReader reads from reader until ctx is done, after which every read
+// fails with the context's error.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
+
+// closeUploadPipe closes the write end of an upload pipe so that reads from
+// the pipe fail with err and blocked writes return.
+func closeUploadPipe(writerInput io.WriteCloser, err error) {
+	if pipe, ok := writerInput.(*io.PipeWriter); ok {
+		_ = pipe.CloseWithError(err)
+		return
+	}
+	_ = writerInput.Close()
+}
+
+// createBuildpackFormFile creates the "buildpack" file part with the
+// client's buildpack content type, rather than one based on the file name.
+func (client *Client) createBuildpackFormFile(form *multipart.Writer, bpPath string) (io.Writer, error) {
+	return form.CreatePart(client.buildpackFilePartHeader(bpPath))
+}
+
+// buildpackFilePartHeader returns the header of the "buildpack" file part.
+// The file name is the base name of bpPath unless the client has an upload
+// filename.
+func (client *Client) buildpackFilePartHeader(bpPath string) textproto.MIMEHeader {
+	filename := filepath.Base(bpPath)
+	if client.uploadFilename != "" {
+		filename = client.uploadFilename
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="buildpack"; filename="%s"`, quoteEscaper.Replace(filename)))
+	header.Set("Content-Type", client.buildpackContentType)
+	return header
+}
+
+// buildpackMetadataPartHeader returns the header of the "metadata" field.
+func buildpackMetadataPartHeader() textproto.MIMEHeader {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="metadata"`)
+	return header
+}
+
+// writeBuildpackMetadataField writes metadata as the "metadata" form field.
+// Nothing is written if metadata is nil.
+func writeBuildpackMetadataField(form *multipart.Writer, metadata json.RawMessage) error {
+	if metadata == nil {
+		return nil
+	}
+
+	writer, err := form.CreatePart(buildpackMetadataPartHeader())
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(metadata)
+	return err
+}
+
+func (client *Client) uploadBuildpackAsynchronously(request *cloudcontroller.Request, cancel context.CancelFunc, bodyWriter io.WriteCloser, writeErrors <-chan error) (Buildpack, Warnings, error) {
 
 	var buildpack Buildpack
 	response := cloudcontroller.Response{
-		Result: &buildpack,
+		Result:      &buildpack,
+		MaxBodySize: client.maxBuildpackResponseSize,
+	}
+
+	// When the upload times out, the request and the body writer are
+	// canceled and the pipe is closed so that neither goroutine is left
+	// blocked on the other.
+	var timeout <-chan time.Time
+	if client.uploadTimeout > 0 {
+		timer := time.NewTimer(client.uploadTimeout)
+		defer timer.Stop()
+		timeout = timer.C
 	}
 
 	httpErrors := make(chan error)
@@ -218,7 +2158,7 @@ func (client *Client) uploadBuildpackAsynchronously(request *cloudcontroller.Req
 	go func() {
 		defer close(httpErrors)
 
-		err := client.connection.Make(request, &response)
+		err := client.makeBuildpackWrite(request, &response)
 		if err != nil {
 			httpErrors <- err
 		}
@@ -250,6 +2190,14 @@ func (client *Client) uploadBuildpackAsynchronously(request *cloudcontroller.Req
 			if firstError == nil {
 				firstError = httpErr
 			}
+		case <-timeout:
+			timeoutErr := ccerror.UploadTimeoutError{Timeout: client.uploadTimeout}
+			if firstError == nil {
+				firstError = timeoutErr
+			}
+			cancel()
+			closeUploadPipe(bodyWriter, timeoutErr)
+			timeout = nil
 		}
 
 		if writeClosed && httpClosed {
@@ -257,5 +2205,9 @@ func (client *Client) uploadBuildpackAsynchronously(request *cloudcontroller.Req
 		}
 	}
 
+	if multiErr, ok := responseMultiError(firstError, response); ok {
+		firstError = multiErr
+	}
+
 	return buildpack, response.Warnings, firstError
 }
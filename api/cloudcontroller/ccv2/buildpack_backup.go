@@ -0,0 +1,108 @@
+package ccv2
+
+import (
+	"io"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/internal"
+)
+
+// BackupBuildpack returns the buildpack with the provided GUID and writes its
+// bits to bitsWriter, so that both can be saved together. When no bits have
+// been uploaded for the buildpack, HasBits is false and nothing is written.
+//
+// The Cloud Controller redirects the download to the blobstore, and the bits
+// are streamed to bitsWriter as they are downloaded rather than held in
+// memory. If the download fails part way, bitsWriter will have received only
+// some of the bits.
+func (client *Client) BackupBuildpack(guid string, bitsWriter io.Writer) (BuildpackDetails, Warnings, error) {
+	details, allWarnings, err := client.GetBuildpackDetails(guid)
+	if err != nil || !details.HasBits {
+		return details, allWarnings, err
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.GetBuildpackDownloadRequest,
+		URIParams:   Params{"buildpack_guid": guid},
+	})
+	if err != nil {
+		return BuildpackDetails{}, allWarnings, err
+	}
+
+	response := cloudcontroller.Response{BodyWriter: bitsWriter}
+	err = client.connection.Make(request, &response)
+	allWarnings = append(allWarnings, response.Warnings...)
+	if err != nil {
+		return BuildpackDetails{}, allWarnings, err
+	}
+
+	return details, allWarnings, nil
+}
+
+// RestoreBuildpack creates a buildpack from metadata saved by BackupBuildpack
+// and uploads bits to it, returning the created buildpack. The saved GUID is
+// not reused; the Cloud Controller assigns a new one. If bitsLength is zero,
+// no bits are uploaded.
+//
+// If the upload fails, the created buildpack is deleted so that a failed
+// restore does not leave a buildpack without bits behind, and the upload
+// error is returned. If the delete also fails, a
+// ccerror.BuildpackRestoreRollbackError with both errors is returned instead.
+//
+// A locked buildpack is locked once its bits are uploaded. If locking fails,
+// the restored buildpack is returned unlocked along with the error.
+func (client *Client) RestoreBuildpack(metadata Buildpack, bits io.Reader, bitsLength int64) (Buildpack, Warnings, error) {
+	metadata.GUID = ""
+	created, allWarnings, err := client.CreateBuildpack(metadata)
+	if err != nil {
+		return Buildpack{}, allWarnings, err
+	}
+
+	if bitsLength != 0 {
+		warnings, uploadErr := client.uploadRestoredBuildpackBits(created.GUID, metadata, bits, bitsLength)
+		allWarnings = append(allWarnings, warnings...)
+		if uploadErr != nil {
+			return Buildpack{}, allWarnings, uploadErr
+		}
+	}
+
+	if !metadata.Locked {
+		return created, allWarnings, nil
+	}
+
+	created.Locked = true
+	locked, warnings, err := client.UpdateBuildpack(created)
+	allWarnings = append(allWarnings, warnings...)
+	if err != nil {
+		created.Locked = false
+		return created, allWarnings, err
+	}
+
+	return locked, allWarnings, nil
+}
+
+// uploadRestoredBuildpackBits uploads the bits of a restored buildpack,
+// deleting the buildpack if the upload fails.
+func (client *Client) uploadRestoredBuildpackBits(guid string, metadata Buildpack, bits io.Reader, bitsLength int64) (Warnings, error) {
+	filename := metadata.Filename
+	if filename == "" {
+		filename = metadata.Name + ".zip"
+	}
+
+	allWarnings, err := client.UploadBuildpack(guid, filename, bits, bitsLength)
+	if err == nil {
+		return allWarnings, nil
+	}
+
+	warnings, deleteErr := client.DeleteBuildpack(guid)
+	allWarnings = append(allWarnings, warnings...)
+	if deleteErr != nil {
+		return allWarnings, ccerror.BuildpackRestoreRollbackError{
+			BuildpackGUID: guid,
+			Err:           err,
+			RollbackErr:   deleteErr,
+		}
+	}
+	return allWarnings, err
+}
@@ -0,0 +1,325 @@
+package ccv2_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("BackupBuildpack", func() {
+	var (
+		client     *Client
+		bits       *bytes.Buffer
+		bitsWriter io.Writer
+		details    BuildpackDetails
+		warnings   Warnings
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		client = NewTestClient()
+		bits = &bytes.Buffer{}
+		bitsWriter = bits
+
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid"),
+				RespondWith(http.StatusOK, `{
+					"metadata": {"guid": "some-bp-guid"},
+					"entity": {"name": "some-bp", "stack": "cflinuxfs2", "position": 1, "enabled": true, "filename": "some-bp.zip"}
+				}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+			),
+		)
+	})
+
+	JustBeforeEach(func() {
+		details, warnings, executeErr = client.BackupBuildpack("some-bp-guid", bitsWriter)
+	})
+
+	Context("when the buildpack has bits", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodHead, "/v2/buildpacks/some-bp-guid/download"),
+					RespondWith(http.StatusOK, nil, http.Header{"Content-Length": {"13"}}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid/download"),
+					RespondWith(http.StatusFound, nil, http.Header{
+						"X-Cf-Warnings": {"download warning"},
+						"Location":      {"/blobstore/some-bp.zip"},
+					}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/blobstore/some-bp.zip"),
+					RespondWith(http.StatusOK, "some-zip-bits"),
+				),
+			)
+		})
+
+		It("returns the buildpack and writes its bits", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(details.Name).To(Equal("some-bp"))
+			Expect(details.HasBits).To(BeTrue())
+			Expect(details.Size).To(BeEquivalentTo(13))
+			Expect(bits.String()).To(Equal("some-zip-bits"))
+			Expect(warnings).To(ConsistOf("get warning"))
+		})
+	})
+
+	Context("when the bits are large", func() {
+		var writes int
+
+		BeforeEach(func() {
+			writes = 0
+			bitsWriter = writerFunc(func(p []byte) (int, error) {
+				writes++
+				return bits.Write(p)
+			})
+
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodHead, "/v2/buildpacks/some-bp-guid/download"),
+					RespondWith(http.StatusOK, nil, http.Header{"Content-Length": {"1048576"}}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid/download"),
+					RespondWith(http.StatusOK, strings.Repeat("a", 1048576)),
+				),
+			)
+		})
+
+		It("streams the bits to the writer as they are downloaded", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(bits.Len()).To(Equal(1048576))
+			Expect(writes).To(BeNumerically(">", 1))
+		})
+	})
+
+	Context("when the buildpack has no bits", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodHead, "/v2/buildpacks/some-bp-guid/download"),
+					RespondWith(http.StatusNotFound, nil, http.Header{"X-Cf-Warnings": {"head warning"}}),
+				),
+			)
+		})
+
+		It("returns the buildpack without writing anything", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(details.Name).To(Equal("some-bp"))
+			Expect(details.HasBits).To(BeFalse())
+			Expect(bits.Len()).To(BeZero())
+			Expect(warnings).To(ConsistOf("get warning", "head warning"))
+		})
+	})
+
+	Context("when the download fails", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodHead, "/v2/buildpacks/some-bp-guid/download"),
+					RespondWith(http.StatusOK, nil, http.Header{"Content-Length": {"13"}}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid/download"),
+					RespondWith(http.StatusTeapot, `{}`, http.Header{"X-Cf-Warnings": {"download warning"}}),
+				),
+			)
+		})
+
+		It("returns the error and all warnings", func() {
+			Expect(executeErr).To(HaveOccurred())
+			Expect(bits.Len()).To(BeZero())
+			Expect(warnings).To(ConsistOf("get warning", "download warning"))
+		})
+	})
+})
+
+var _ = Describe("RestoreBuildpack", func() {
+	var (
+		client     *Client
+		metadata   Buildpack
+		bitsLength int64
+		buildpack  Buildpack
+		warnings   Warnings
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		client = NewTestClient()
+		metadata = Buildpack{GUID: "old-bp-guid", Name: "some-bp", Stack: "cflinuxfs2", Position: 3, Enabled: true, Filename: "some-bp-v1.zip"}
+		bitsLength = 13
+
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPost, "/v2/buildpacks"),
+				VerifyJSONRepresenting(map[string]interface{}{
+					"name":     "some-bp",
+					"stack":    "cflinuxfs2",
+					"position": 3,
+					"enabled":  true,
+				}),
+				RespondWith(http.StatusCreated, `{
+					"metadata": {"guid": "new-bp-guid"},
+					"entity": {"name": "some-bp", "stack": "cflinuxfs2", "position": 3, "enabled": true}
+				}`, http.Header{"X-Cf-Warnings": {"create warning"}}),
+			),
+		)
+	})
+
+	JustBeforeEach(func() {
+		buildpack, warnings, executeErr = client.RestoreBuildpack(metadata, strings.NewReader("some-zip-bits"), bitsLength)
+	})
+
+	Context("when the bits are uploaded", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/new-bp-guid/bits"),
+					func(_ http.ResponseWriter, req *http.Request) {
+						body, err := ioutil.ReadAll(req.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring(`filename="some-bp-v1.zip"`))
+						Expect(string(body)).To(ContainSubstring("some-zip-bits"))
+					},
+					RespondWith(http.StatusCreated, "{}", http.Header{"X-Cf-Warnings": {"upload warning"}}),
+				),
+			)
+		})
+
+		It("returns the created buildpack and all warnings", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(buildpack.GUID).To(Equal("new-bp-guid"))
+			Expect(warnings).To(ConsistOf("create warning", "upload warning"))
+		})
+	})
+
+	Context("when there are no bits", func() {
+		BeforeEach(func() {
+			bitsLength = 0
+		})
+
+		It("only creates the buildpack", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(buildpack.GUID).To(Equal("new-bp-guid"))
+			Expect(warnings).To(ConsistOf("create warning"))
+		})
+	})
+
+	Context("when the upload fails", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/new-bp-guid/bits"),
+					func(_ http.ResponseWriter, req *http.Request) {
+						_, err := ioutil.ReadAll(req.Body)
+						Expect(err).ToNot(HaveOccurred())
+					},
+					RespondWith(http.StatusTeapot, `{"code": 1, "description": "some error"}`, http.Header{"X-Cf-Warnings": {"upload warning"}}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodDelete, "/v2/buildpacks/new-bp-guid"),
+					RespondWith(http.StatusNoContent, nil, http.Header{"X-Cf-Warnings": {"delete warning"}}),
+				),
+			)
+		})
+
+		It("deletes the created buildpack and returns the upload error", func() {
+			Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+				ResponseCode:    http.StatusTeapot,
+				V2ErrorResponse: ccerror.V2ErrorResponse{Code: 1, Description: "some error"},
+			}))
+			Expect(buildpack).To(Equal(Buildpack{}))
+			Expect(warnings).To(ConsistOf("create warning", "upload warning", "delete warning"))
+		})
+	})
+
+	Context("when the upload fails and the created buildpack cannot be deleted", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/new-bp-guid/bits"),
+					func(_ http.ResponseWriter, req *http.Request) {
+						_, err := ioutil.ReadAll(req.Body)
+						Expect(err).ToNot(HaveOccurred())
+					},
+					RespondWith(http.StatusTeapot, `{"code": 1, "description": "some error"}`, http.Header{"X-Cf-Warnings": {"upload warning"}}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodDelete, "/v2/buildpacks/new-bp-guid"),
+					RespondWith(http.StatusTeapot, `{"code": 2, "description": "delete error"}`, http.Header{"X-Cf-Warnings": {"delete warning"}}),
+				),
+			)
+		})
+
+		It("returns both the upload and the delete errors", func() {
+			Expect(executeErr).To(MatchError(ccerror.BuildpackRestoreRollbackError{
+				BuildpackGUID: "new-bp-guid",
+				Err: ccerror.V2UnexpectedResponseError{
+					ResponseCode:    http.StatusTeapot,
+					V2ErrorResponse: ccerror.V2ErrorResponse{Code: 1, Description: "some error"},
+				},
+				RollbackErr: ccerror.V2UnexpectedResponseError{
+					ResponseCode:    http.StatusTeapot,
+					V2ErrorResponse: ccerror.V2ErrorResponse{Code: 2, Description: "delete error"},
+				},
+			}))
+			Expect(buildpack).To(Equal(Buildpack{}))
+			Expect(warnings).To(ConsistOf("create warning", "upload warning", "delete warning"))
+		})
+	})
+
+	Context("when the buildpack was locked", func() {
+		BeforeEach(func() {
+			metadata.Locked = true
+
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/new-bp-guid/bits"),
+					func(_ http.ResponseWriter, req *http.Request) {
+						_, err := ioutil.ReadAll(req.Body)
+						Expect(err).ToNot(HaveOccurred())
+					},
+					RespondWith(http.StatusCreated, "{}", http.Header{"X-Cf-Warnings": {"upload warning"}}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/new-bp-guid"),
+					VerifyJSONRepresenting(map[string]interface{}{
+						"name":     "some-bp",
+						"stack":    "cflinuxfs2",
+						"position": 3,
+						"enabled":  true,
+						"locked":   true,
+					}),
+					RespondWith(http.StatusCreated, `{
+						"metadata": {"guid": "new-bp-guid"},
+						"entity": {"name": "some-bp", "stack": "cflinuxfs2", "position": 3, "enabled": true, "locked": true}
+					}`, http.Header{"X-Cf-Warnings": {"update warning"}}),
+				),
+			)
+		})
+
+		It("locks the buildpack after uploading its bits", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(buildpack.GUID).To(Equal("new-bp-guid"))
+			Expect(buildpack.Locked).To(BeTrue())
+			Expect(warnings).To(ConsistOf("create warning", "upload warning", "update warning"))
+		})
+	})
+})
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,63 @@
+package ccv2
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+//go:generate counterfeiter . BuildpackClient
+
+// BuildpackClient is the subset of Client used to manage buildpacks. Code
+// that only manages buildpacks can depend on it instead of Client so that a
+// fake can be substituted in tests.
+type BuildpackClient interface {
+	BackupBuildpack(guid string, bitsWriter io.Writer) (BuildpackDetails, Warnings, error)
+	BulkReorderBuildpacks(orderedGUIDs []string) (Warnings, error)
+	CreateBuildpack(buildpack Buildpack) (Buildpack, Warnings, error)
+	CreateBuildpackAtEnd(buildpack Buildpack) (Buildpack, Warnings, error)
+	DeleteBuildpack(guid string) (Warnings, error)
+	DeleteBuildpackSafe(guid string, force bool) (Warnings, error)
+	DetectBuildpackDrift(desired []Buildpack) (DriftReport, Warnings, error)
+	DiffBuildpackSets(other *Client) (BuildpackSetDiff, Warnings, error)
+	FindDuplicateBuildpackNames() (map[string][]Buildpack, Warnings, error)
+	GetBuildpack(guid string) (Buildpack, Warnings, error)
+	GetBuildpackByNameAndStack(name string, stack string) (Buildpack, Warnings, error)
+	GetBuildpackByPosition(position int, stack string) (Buildpack, Warnings, error)
+	GetBuildpackDetails(guid string) (BuildpackDetails, Warnings, error)
+	GetBuildpackEvents(guid string) ([]Event, Warnings, error)
+	GetBuildpackGUID(name string, stack string) (string, Warnings, error)
+	GetBuildpacks(filters ...Filter) ([]Buildpack, Warnings, error)
+	GetBuildpacksByGUIDs(guids []string) ([]Buildpack, Warnings, error)
+	GetBuildpacksByNamePrefix(prefix string) ([]Buildpack, Warnings, error)
+	GetBuildpacksByNames(names []string) ([]Buildpack, Warnings, error)
+	GetBuildpacksForDisplay() ([]BuildpackDisplayRow, Warnings, error)
+	GetBuildpacksMap(filters ...Filter) (map[string]Buildpack, Warnings, error)
+	GetBuildpacksWithOptions(options GetBuildpacksOptions) ([]Buildpack, Warnings, error)
+	GetBuildpacksWithStop(stop <-chan struct{}, filters ...Filter) ([]Buildpack, Warnings, error)
+	GetRecentBuildpacks(limit int) ([]Buildpack, Warnings, error)
+	HeadBuildpackBits(guid string) (bool, int64, Warnings, error)
+	MustGetBuildpacks(filters ...Filter) ([]Buildpack, Warnings, error)
+	PingBuildpacksEndpoint() (Warnings, error)
+	PrepareBuildpackUpload(buildpackPath string) (PreparedBuildpackUpload, func() error, error)
+	PreviewBuildpackReorder(guid string, newPosition int) ([]BuildpackReorder, Warnings, error)
+	ReconcileBuildpacks(desired []Buildpack) (BuildpackReconcileResult, Warnings, error)
+	RenameBuildpack(guid string, newName string) (Buildpack, Warnings, error)
+	RestoreBuildpack(metadata Buildpack, bits io.Reader, bitsLength int64) (Buildpack, Warnings, error)
+	RetryBuildpackUpload(name string, stack string, buildpackPath string) (Warnings, error)
+	SetBuildpackOrder(orderedNames []string, stack string) (Warnings, error)
+	StreamBuildpacks(filters ...Filter) (<-chan Buildpack, <-chan error)
+	UpdateBuildpack(buildpack Buildpack) (Buildpack, Warnings, error)
+	UpdateBuildpackIfUnmodified(buildpack Buildpack, expectedUpdatedAt time.Time) (Buildpack, Warnings, error)
+	UploadBuildpack(buildpackGUID string, buildpackPath string, buildpack io.Reader, buildpackLength int64) (Warnings, error)
+	UploadBuildpackFromSizer(buildpackGUID string, buildpackPath string, buildpack SizedReader) (Warnings, error)
+	UploadBuildpackWithMetadata(buildpackGUID string, buildpackPath string, buildpack io.Reader, buildpackLength int64, metadata json.RawMessage) (Warnings, error)
+	UploadBuildpackWithTimings(buildpackGUID string, buildpackPath string, buildpack io.Reader, buildpackLength int64) (UploadTimings, Warnings, error)
+	UploadBuildpacks(specs []BuildpackUploadSpec) ([]Warnings, error)
+	UploadBuildpacksWithOptions(specs []BuildpackUploadSpec, options UploadBuildpacksOptions) ([]Warnings, error)
+	UploadPreparedBuildpack(buildpackGUID string, prepared PreparedBuildpackUpload) (Warnings, error)
+	UpsertBuildpack(buildpack Buildpack) (Buildpack, bool, Warnings, error)
+	ValidateBuildpackForFoundation(buildpack Buildpack) (Warnings, error)
+}
+
+var _ BuildpackClient = (*Client)(nil)
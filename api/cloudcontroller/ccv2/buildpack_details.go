@@ -0,0 +1,36 @@
+package ccv2
+
+// BuildpackDetails is a buildpack together with information about its bits.
+type BuildpackDetails struct {
+	Buildpack
+
+	// HasBits is true when bits have been uploaded for the buildpack.
+	HasBits bool
+
+	// Size is the size of the buildpack's bits in bytes. It is zero when no
+	// bits have been uploaded.
+	Size int64
+}
+
+// GetBuildpackDetails returns the buildpack with the provided GUID and the
+// size of its bits. A buildpack without bits is not an error.
+//
+// The V2 API does not report a checksum of the bits, so none is returned.
+func (client *Client) GetBuildpackDetails(guid string) (BuildpackDetails, Warnings, error) {
+	buildpack, allWarnings, err := client.GetBuildpack(guid)
+	if err != nil {
+		return BuildpackDetails{}, allWarnings, err
+	}
+
+	hasBits, size, warnings, err := client.HeadBuildpackBits(guid)
+	allWarnings = append(allWarnings, warnings...)
+	if err != nil {
+		return BuildpackDetails{}, allWarnings, err
+	}
+
+	return BuildpackDetails{
+		Buildpack: buildpack,
+		HasBits:   hasBits,
+		Size:      size,
+	}, allWarnings, nil
+}
@@ -0,0 +1,113 @@
+package ccv2_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("BuildpackDetails", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("GetBuildpackDetails", func() {
+		var (
+			details    BuildpackDetails
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			details, warnings, executeErr = client.GetBuildpackDetails("some-bp-guid")
+		})
+
+		Context("when the buildpack exists", func() {
+			BeforeEach(func() {
+				response := `{
+					"metadata": {"guid": "some-bp-guid"},
+					"entity": {"name": "some-bp", "stack": "cflinuxfs2", "position": 1, "enabled": true, "locked": true, "filename": "some-bp.zip"}
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+				)
+			})
+
+			Context("when the buildpack has bits", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodHead, "/v2/buildpacks/some-bp-guid/download"),
+							RespondWith(http.StatusOK, nil, http.Header{
+								"X-Cf-Warnings":  {"head warning"},
+								"Content-Length": {"1024"},
+							}),
+						),
+					)
+				})
+
+				It("returns the buildpack with the size of its bits and all warnings", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf("get warning", "head warning"))
+					Expect(details).To(Equal(BuildpackDetails{
+						Buildpack: Buildpack{
+							GUID:     "some-bp-guid",
+							Name:     "some-bp",
+							Stack:    "cflinuxfs2",
+							Position: 1,
+							Enabled:  true,
+							Locked:   true,
+							Filename: "some-bp.zip",
+						},
+						HasBits: true,
+						Size:    1024,
+					}))
+				})
+			})
+
+			Context("when the buildpack has no bits", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodHead, "/v2/buildpacks/some-bp-guid/download"),
+							RespondWith(http.StatusNotFound, nil),
+						),
+					)
+				})
+
+				It("returns the buildpack with a zero size", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(details.Name).To(Equal("some-bp"))
+					Expect(details.HasBits).To(BeFalse())
+					Expect(details.Size).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the buildpack does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusNotFound, `{"code": 10000, "description": "The buildpack could not be found: some-bp-guid", "error_code": "CF-BuildpackNotFound"}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings without checking the bits", func() {
+				Expect(executeErr).To(MatchError(ccerror.ResourceNotFoundError{Message: "The buildpack could not be found: some-bp-guid"}))
+				Expect(warnings).To(ConsistOf("get warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+})
@@ -0,0 +1,48 @@
+package ccv2
+
+import "sort"
+
+// BuildpackDisplayRow holds the fields of a buildpack that are shown when
+// listing buildpacks, in the order they are displayed.
+type BuildpackDisplayRow struct {
+	Position int
+	Name     string
+	Stack    string
+	Enabled  bool
+	Locked   bool
+	Filename string
+}
+
+// GetBuildpacksForDisplay returns a row for every buildpack, ordered by
+// position. Buildpacks that share a position are ordered by name and then
+// stack so that the order is the same on every call.
+func (client *Client) GetBuildpacksForDisplay() ([]BuildpackDisplayRow, Warnings, error) {
+	buildpacks, warnings, err := client.GetBuildpacks()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	sort.SliceStable(buildpacks, func(i int, j int) bool {
+		if buildpacks[i].Position != buildpacks[j].Position {
+			return buildpacks[i].Position < buildpacks[j].Position
+		}
+		if buildpacks[i].Name != buildpacks[j].Name {
+			return buildpacks[i].Name < buildpacks[j].Name
+		}
+		return buildpacks[i].Stack < buildpacks[j].Stack
+	})
+
+	rows := make([]BuildpackDisplayRow, 0, len(buildpacks))
+	for _, buildpack := range buildpacks {
+		rows = append(rows, BuildpackDisplayRow{
+			Position: buildpack.Position,
+			Name:     buildpack.Name,
+			Stack:    buildpack.Stack,
+			Enabled:  buildpack.Enabled,
+			Locked:   buildpack.Locked,
+			Filename: buildpack.Filename,
+		})
+	}
+
+	return rows, warnings, nil
+}
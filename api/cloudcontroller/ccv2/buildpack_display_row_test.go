@@ -0,0 +1,84 @@
+package ccv2_test
+
+import (
+	"net/http"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("BuildpackDisplayRow", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("GetBuildpacksForDisplay", func() {
+		var (
+			rows       []BuildpackDisplayRow
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			rows, warnings, executeErr = client.GetBuildpacksForDisplay()
+		})
+
+		Context("when the buildpacks exist", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "bp-3-guid"},
+							"entity": {"name": "bp-3", "stack": "cflinuxfs2", "position": 2, "enabled": false, "locked": true, "filename": "bp-3.zip"}
+						},
+						{
+							"metadata": {"guid": "bp-2-guid"},
+							"entity": {"name": "bp-2", "stack": "cflinuxfs3", "position": 2, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "bp-1-guid"},
+							"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1, "enabled": true, "filename": "bp-1.zip"}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the rows ordered by position, name and stack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(rows).To(Equal([]BuildpackDisplayRow{
+					{Position: 1, Name: "bp-1", Stack: "cflinuxfs2", Enabled: true, Filename: "bp-1.zip"},
+					{Position: 2, Name: "bp-2", Stack: "cflinuxfs3", Enabled: true},
+					{Position: 2, Name: "bp-3", Stack: "cflinuxfs2", Locked: true, Filename: "bp-3.zip"},
+				}))
+			})
+		})
+
+		Context("when the cloud controller returns an error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusTeapot, `{"code": 10001, "description": "Some Error", "error_code": "CF-SomeError"}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+})
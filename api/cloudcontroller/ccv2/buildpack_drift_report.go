@@ -0,0 +1,160 @@
+package ccv2
+
+import (
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+)
+
+// DriftReport describes the changes needed for the Cloud Controller's
+// buildpacks to match a desired set of buildpacks. It can be marshaled to JSON
+// to display a plan.
+type DriftReport struct {
+	// Additions are the desired buildpacks that do not exist.
+	Additions []Buildpack `json:"additions"`
+
+	// Removals are the existing buildpacks that are not desired.
+	Removals []Buildpack `json:"removals"`
+
+	// Changes are the settings, other than position, that differ between an
+	// existing buildpack and its desired counterpart.
+	Changes []BuildpackFieldChange `json:"changes"`
+
+	// Reorders are the existing buildpacks whose position differs from the
+	// desired position.
+	Reorders []BuildpackReorder `json:"reorders"`
+}
+
+// BuildpackFieldChange describes a single setting of a buildpack that differs
+// from the desired value.
+type BuildpackFieldChange struct {
+	Name    string      `json:"name"`
+	Stack   string      `json:"stack,omitempty"`
+	Field   string      `json:"field"`
+	Current interface{} `json:"current"`
+	Desired interface{} `json:"desired"`
+}
+
+// BuildpackReorder describes a buildpack that needs to move to a different
+// position.
+type BuildpackReorder struct {
+	Name            string `json:"name"`
+	Stack           string `json:"stack,omitempty"`
+	CurrentPosition int    `json:"current_position"`
+	DesiredPosition int    `json:"desired_position"`
+}
+
+// HasDrift returns true if any buildpack needs to be added, removed, changed,
+// or moved.
+func (report DriftReport) HasDrift() bool {
+	return len(report.Additions) > 0 || len(report.Removals) > 0 ||
+		len(report.Changes) > 0 || len(report.Reorders) > 0
+}
+
+// DetectBuildpackDrift compares the Cloud Controller's buildpacks with the
+// desired buildpacks and reports the differences without changing anything.
+// Buildpacks are matched by name and stack, the same way as
+// ReconcileBuildpacks. A desired Position of 0 means the position is not
+// compared.
+func (client *Client) DetectBuildpackDrift(desired []Buildpack) (DriftReport, Warnings, error) {
+	report := DriftReport{
+		Additions: []Buildpack{},
+		Removals:  []Buildpack{},
+		Changes:   []BuildpackFieldChange{},
+		Reorders:  []BuildpackReorder{},
+	}
+
+	current, warnings, err := client.GetBuildpacks()
+	if err != nil {
+		return report, warnings, err
+	}
+
+	currentByKey := map[string]Buildpack{}
+	for _, buildpack := range current {
+		currentByKey[buildpackKey(buildpack)] = buildpack
+	}
+
+	desiredKeys := map[string]bool{}
+	for _, buildpack := range desired {
+		desiredKeys[buildpackKey(buildpack)] = true
+
+		existing, exists := currentByKey[buildpackKey(buildpack)]
+		if !exists {
+			report.Additions = append(report.Additions, buildpack)
+			continue
+		}
+
+		if existing.Enabled != buildpack.Enabled {
+			report.Changes = append(report.Changes, BuildpackFieldChange{
+				Name:    existing.Name,
+				Stack:   existing.Stack,
+				Field:   "enabled",
+				Current: existing.Enabled,
+				Desired: buildpack.Enabled,
+			})
+		}
+
+		if buildpack.Position != 0 && existing.Position != buildpack.Position {
+			report.Reorders = append(report.Reorders, BuildpackReorder{
+				Name:            existing.Name,
+				Stack:           existing.Stack,
+				CurrentPosition: existing.Position,
+				DesiredPosition: buildpack.Position,
+			})
+		}
+	}
+
+	for _, buildpack := range current {
+		if !desiredKeys[buildpackKey(buildpack)] {
+			report.Removals = append(report.Removals, buildpack)
+		}
+	}
+
+	return report, warnings, nil
+}
+
+// PreviewBuildpackReorder returns the other buildpacks whose positions would
+// change if the buildpack with the provided GUID were moved to newPosition,
+// without changing anything. Positions below 1 move it to the start and
+// positions past the last buildpack move it to the end. A
+// ccerror.ResourceNotFoundError is returned if no buildpack has the GUID.
+func (client *Client) PreviewBuildpackReorder(guid string, newPosition int) ([]BuildpackReorder, Warnings, error) {
+	if guid == "" {
+		return nil, nil, ccerror.EmptyBuildpackGUIDError{}
+	}
+
+	buildpacks, warnings, err := client.GetBuildpacks()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	currentPositions := map[string]int{}
+	for _, buildpack := range buildpacks {
+		currentPositions[buildpack.GUID] = buildpack.Position
+	}
+	if _, exists := currentPositions[guid]; !exists {
+		return nil, warnings, ccerror.ResourceNotFoundError{
+			Message: fmt.Sprintf("The buildpack could not be found: %s", guid),
+		}
+	}
+
+	sort.SliceStable(buildpacks, func(i int, j int) bool {
+		return buildpacks[i].Position < buildpacks[j].Position
+	})
+
+	reorders := []BuildpackReorder{}
+	for _, buildpack := range moveBuildpack(buildpacks, guid, newPosition) {
+		if buildpack.GUID == guid || buildpack.Position == currentPositions[buildpack.GUID] {
+			continue
+		}
+		reorders = append(reorders, BuildpackReorder{
+			Name:            buildpack.Name,
+			Stack:           buildpack.Stack,
+			CurrentPosition: currentPositions[buildpack.GUID],
+			DesiredPosition: buildpack.Position,
+		})
+	}
+
+	return reorders, warnings, nil
+}
@@ -0,0 +1,262 @@
+package ccv2_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("DriftReport", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("DetectBuildpackDrift", func() {
+		var (
+			desired    []Buildpack
+			report     DriftReport
+			warnings   Warnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			desired = []Buildpack{
+				{Name: "bp-1", Stack: "cflinuxfs2", Position: 2, Enabled: true},
+				{Name: "bp-2", Stack: "cflinuxfs2", Position: 1, Enabled: false},
+				{Name: "bp-4", Stack: "cflinuxfs2", Position: 3, Enabled: true},
+			}
+		})
+
+		JustBeforeEach(func() {
+			report, warnings, executeErr = client.DetectBuildpackDrift(desired)
+		})
+
+		Context("when the current buildpacks differ from the desired buildpacks", func() {
+			BeforeEach(func() {
+				listResponse := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "bp-1-guid"},
+							"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "bp-2-guid"},
+							"entity": {"name": "bp-2", "stack": "cflinuxfs2", "position": 2, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "bp-3-guid"},
+							"entity": {"name": "bp-3", "stack": "cflinuxfs2", "position": 3, "enabled": true}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, listResponse, http.Header{"X-Cf-Warnings": {"list warning"}}),
+					),
+				)
+			})
+
+			It("reports the differences without changing anything", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("list warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+
+				Expect(report.HasDrift()).To(BeTrue())
+				Expect(report.Additions).To(ConsistOf(Buildpack{Name: "bp-4", Stack: "cflinuxfs2", Position: 3, Enabled: true}))
+				Expect(report.Removals).To(ConsistOf(Buildpack{GUID: "bp-3-guid", Name: "bp-3", Stack: "cflinuxfs2", Position: 3, Enabled: true}))
+				Expect(report.Changes).To(ConsistOf(BuildpackFieldChange{
+					Name:    "bp-2",
+					Stack:   "cflinuxfs2",
+					Field:   "enabled",
+					Current: true,
+					Desired: false,
+				}))
+				Expect(report.Reorders).To(ConsistOf(
+					BuildpackReorder{Name: "bp-1", Stack: "cflinuxfs2", CurrentPosition: 1, DesiredPosition: 2},
+					BuildpackReorder{Name: "bp-2", Stack: "cflinuxfs2", CurrentPosition: 2, DesiredPosition: 1},
+				))
+			})
+
+			It("can be marshaled to JSON", func() {
+				data, err := json.Marshal(report)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(data).To(MatchJSON(`{
+					"additions": [{"enabled": true, "name": "bp-4", "position": 3, "stack": "cflinuxfs2"}],
+					"removals": [{"enabled": true, "guid": "bp-3-guid", "name": "bp-3", "position": 3, "stack": "cflinuxfs2"}],
+					"changes": [{"name": "bp-2", "stack": "cflinuxfs2", "field": "enabled", "current": true, "desired": false}],
+					"reorders": [
+						{"name": "bp-1", "stack": "cflinuxfs2", "current_position": 1, "desired_position": 2},
+						{"name": "bp-2", "stack": "cflinuxfs2", "current_position": 2, "desired_position": 1}
+					]
+				}`))
+			})
+		})
+
+		Context("when the current buildpacks match the desired buildpacks", func() {
+			BeforeEach(func() {
+				desired = []Buildpack{
+					{Name: "bp-1", Stack: "cflinuxfs2", Enabled: true},
+				}
+				listResponse := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "bp-1-guid"},
+							"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 4, "enabled": true}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, listResponse),
+					),
+				)
+			})
+
+			It("reports no drift", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(report.HasDrift()).To(BeFalse())
+			})
+		})
+
+		Context("when listing the buildpacks fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusTeapot, `{"code": 10001, "description": "Some Error", "error_code": "CF-SomeError"}`, http.Header{"X-Cf-Warnings": {"list warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+					ResponseCode: http.StatusTeapot,
+					V2ErrorResponse: ccerror.V2ErrorResponse{
+						Code:        10001,
+						Description: "Some Error",
+						ErrorCode:   "CF-SomeError",
+					},
+					RequestIDs: nil,
+				}))
+				Expect(warnings).To(ConsistOf("list warning"))
+			})
+		})
+	})
+
+	Describe("PreviewBuildpackReorder", func() {
+		var (
+			guid        string
+			newPosition int
+			reorders    []BuildpackReorder
+			warnings    Warnings
+			executeErr  error
+		)
+
+		BeforeEach(func() {
+			guid = "bp-4-guid"
+			newPosition = 2
+
+			listResponse := `{
+				"next_url": null,
+				"resources": [
+					{
+						"metadata": {"guid": "bp-3-guid"},
+						"entity": {"name": "bp-3", "stack": "cflinuxfs2", "position": 3}
+					},
+					{
+						"metadata": {"guid": "bp-1-guid"},
+						"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1}
+					},
+					{
+						"metadata": {"guid": "bp-2-guid"},
+						"entity": {"name": "bp-2", "stack": "cflinuxfs2", "position": 2}
+					},
+					{
+						"metadata": {"guid": "bp-4-guid"},
+						"entity": {"name": "bp-4", "stack": "cflinuxfs2", "position": 4}
+					}
+				]
+			}`
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+					RespondWith(http.StatusOK, listResponse, http.Header{"X-Cf-Warnings": {"list warning"}}),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			reorders, warnings, executeErr = client.PreviewBuildpackReorder(guid, newPosition)
+		})
+
+		It("returns the other buildpacks that would shift without changing anything", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf("list warning"))
+			Expect(reorders).To(Equal([]BuildpackReorder{
+				{Name: "bp-2", Stack: "cflinuxfs2", CurrentPosition: 2, DesiredPosition: 3},
+				{Name: "bp-3", Stack: "cflinuxfs2", CurrentPosition: 3, DesiredPosition: 4},
+			}))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		Context("when the buildpack does not move", func() {
+			BeforeEach(func() {
+				newPosition = 4
+			})
+
+			It("returns no reorders", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(reorders).To(BeEmpty())
+			})
+		})
+
+		Context("when the new position is 0", func() {
+			BeforeEach(func() {
+				newPosition = 0
+			})
+
+			It("previews moving the buildpack to the start", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(reorders).To(Equal([]BuildpackReorder{
+					{Name: "bp-1", Stack: "cflinuxfs2", CurrentPosition: 1, DesiredPosition: 2},
+					{Name: "bp-2", Stack: "cflinuxfs2", CurrentPosition: 2, DesiredPosition: 3},
+					{Name: "bp-3", Stack: "cflinuxfs2", CurrentPosition: 3, DesiredPosition: 4},
+				}))
+			})
+		})
+
+		Context("when the new position is negative", func() {
+			BeforeEach(func() {
+				newPosition = -3
+			})
+
+			It("previews moving the buildpack to the start", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(reorders).To(HaveLen(3))
+				Expect(reorders[0]).To(Equal(BuildpackReorder{Name: "bp-1", Stack: "cflinuxfs2", CurrentPosition: 1, DesiredPosition: 2}))
+			})
+		})
+
+		Context("when no buildpack has the GUID", func() {
+			BeforeEach(func() {
+				guid = "missing-guid"
+			})
+
+			It("returns a ResourceNotFoundError and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.ResourceNotFoundError{Message: "The buildpack could not be found: missing-guid"}))
+				Expect(warnings).To(ConsistOf("list warning"))
+			})
+		})
+	})
+})
@@ -0,0 +1,75 @@
+package ccv2
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+)
+
+// buildpackListCache holds the pages of buildpack listings that were
+// received with an ETag, keyed by request URL and headers, so that they can
+// be revalidated instead of downloaded again. It is shared by copies of a
+// client.
+type buildpackListCache struct {
+	mutex sync.Mutex
+	pages map[string]cachedPage
+}
+
+// cachedPage is a page of a listing and the ETag it was received with.
+type cachedPage struct {
+	etag string
+	body []byte
+}
+
+func newBuildpackListCache() *buildpackListCache {
+	return &buildpackListCache{pages: map[string]cachedPage{}}
+}
+
+func (cache *buildpackListCache) get(key string) (cachedPage, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	page, found := cache.pages[key]
+	return page, found
+}
+
+func (cache *buildpackListCache) put(key string, page cachedPage) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.pages[key] = page
+}
+
+// invalidate removes every cached page.
+func (cache *buildpackListCache) invalidate() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.pages = map[string]cachedPage{}
+}
+
+// buildpackListCacheKey returns the key a page of a listing is cached under.
+// Copies of a client can send different headers, such as Accept-Language or
+// the headers added by WithHeaders, so they are part of the key. Headers that
+// wrappers add when the request is made, such as the Authorization header
+// added by the UAA wrapper, are not known yet; a page cached for another
+// token is still revalidated by the Cloud Controller.
+func buildpackListCacheKey(request *http.Request) string {
+	var key bytes.Buffer
+	key.WriteString(request.URL.String())
+	key.WriteString("\n")
+	_ = request.Header.WriteSubset(&key, map[string]bool{"If-None-Match": true})
+	return key.String()
+}
+
+// makeBuildpackWrite makes a request that changes buildpacks and then clears
+// the buildpack list cache. Clearing the cache once the request completes,
+// rather than when it is created, keeps a listing made while the request is
+// in flight from leaving the old buildpacks cached. The cache is cleared even
+// when the request fails, as the change may have been applied.
+func (client *Client) makeBuildpackWrite(request *cloudcontroller.Request, response *cloudcontroller.Response) error {
+	err := client.connection.Make(request, response)
+	if client.buildpackListCache != nil {
+		client.buildpackListCache.invalidate()
+	}
+	return err
+}
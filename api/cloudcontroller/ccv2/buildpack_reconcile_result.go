@@ -0,0 +1,124 @@
+package ccv2
+
+import "sort"
+
+// BuildpackReconcileResult describes the changes ReconcileBuildpacks made to
+// bring the Cloud Controller's buildpacks in line with the desired set.
+type BuildpackReconcileResult struct {
+	// Created are the buildpacks that were created.
+	Created []Buildpack
+
+	// Updated are the buildpacks whose position, enabled state, or locked
+	// state were changed.
+	Updated []Buildpack
+
+	// Deleted are the buildpacks that were deleted.
+	Deleted []Buildpack
+}
+
+// HasChanges returns true if any buildpack was created, updated, or deleted.
+func (result BuildpackReconcileResult) HasChanges() bool {
+	return len(result.Created) > 0 || len(result.Updated) > 0 || len(result.Deleted) > 0
+}
+
+// ReconcileBuildpacks makes the minimal changes needed for the Cloud
+// Controller's buildpacks to match the desired buildpacks. Buildpacks are
+// matched by name and stack. Buildpacks missing from desired are deleted,
+// desired buildpacks that do not exist are created, and existing buildpacks
+// are updated when their position, enabled state, or locked state differ. A
+// desired Position of 0 leaves the position of an existing buildpack
+// unchanged.
+//
+// Running ReconcileBuildpacks again with the same desired buildpacks makes no
+// changes.
+func (client *Client) ReconcileBuildpacks(desired []Buildpack) (BuildpackReconcileResult, Warnings, error) {
+	var result BuildpackReconcileResult
+
+	current, allWarnings, err := client.GetBuildpacks()
+	if err != nil {
+		return result, allWarnings, err
+	}
+
+	currentByKey := map[string]Buildpack{}
+	for _, buildpack := range current {
+		currentByKey[buildpackKey(buildpack)] = buildpack
+	}
+
+	desiredKeys := map[string]bool{}
+	for _, buildpack := range desired {
+		desiredKeys[buildpackKey(buildpack)] = true
+	}
+
+	for _, buildpack := range current {
+		if desiredKeys[buildpackKey(buildpack)] {
+			continue
+		}
+
+		warnings, err := client.DeleteBuildpack(buildpack.GUID)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			return result, allWarnings, err
+		}
+		result.Deleted = append(result.Deleted, buildpack)
+	}
+
+	// Changes are applied in ascending position order so that the Cloud
+	// Controller's position shifting leaves every buildpack where it was
+	// requested.
+	ordered := make([]Buildpack, len(desired))
+	copy(ordered, desired)
+	sort.SliceStable(ordered, func(i int, j int) bool {
+		return ordered[i].Position < ordered[j].Position
+	})
+
+	for _, buildpack := range ordered {
+		existing, exists := currentByKey[buildpackKey(buildpack)]
+		if !exists {
+			created, warnings, err := client.CreateBuildpack(buildpack)
+			allWarnings = append(allWarnings, warnings...)
+			if err != nil {
+				return result, allWarnings, err
+			}
+			result.Created = append(result.Created, created)
+			continue
+		}
+
+		if !buildpackNeedsUpdate(existing, buildpack) {
+			continue
+		}
+
+		updated, warnings, err := client.UpdateBuildpack(applyBuildpackChanges(existing, buildpack))
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			return result, allWarnings, err
+		}
+		result.Updated = append(result.Updated, updated)
+	}
+
+	return result, allWarnings, nil
+}
+
+// buildpackKey identifies a buildpack by its name and stack.
+func buildpackKey(buildpack Buildpack) string {
+	return buildpack.Name + "@" + buildpack.Stack
+}
+
+// applyBuildpackChanges returns current with the settings from desired that
+// buildpackNeedsUpdate compares applied to it.
+func applyBuildpackChanges(current Buildpack, desired Buildpack) Buildpack {
+	current.Enabled = desired.Enabled
+	current.Locked = desired.Locked
+	if desired.Position != 0 {
+		current.Position = desired.Position
+	}
+	return current
+}
+
+// buildpackNeedsUpdate returns true if the desired buildpack's settings differ
+// from the current buildpack's.
+func buildpackNeedsUpdate(current Buildpack, desired Buildpack) bool {
+	if current.Enabled != desired.Enabled || current.Locked != desired.Locked {
+		return true
+	}
+	return desired.Position != 0 && current.Position != desired.Position
+}
@@ -0,0 +1,188 @@
+package ccv2_test
+
+import (
+	"net/http"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("BuildpackReconcileResult", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("ReconcileBuildpacks", func() {
+		var (
+			desired    []Buildpack
+			result     BuildpackReconcileResult
+			warnings   Warnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			desired = []Buildpack{
+				{Name: "bp-1", Stack: "cflinuxfs2", Position: 1, Enabled: true},
+				{Name: "bp-2", Stack: "cflinuxfs2", Position: 2, Enabled: false},
+				{Name: "bp-4", Stack: "cflinuxfs2", Position: 3, Enabled: true},
+			}
+		})
+
+		JustBeforeEach(func() {
+			result, warnings, executeErr = client.ReconcileBuildpacks(desired)
+		})
+
+		Context("when the current buildpacks differ from the desired buildpacks", func() {
+			BeforeEach(func() {
+				listResponse := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "bp-1-guid"},
+							"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "bp-2-guid"},
+							"entity": {"name": "bp-2", "stack": "cflinuxfs2", "position": 2, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "bp-3-guid"},
+							"entity": {"name": "bp-3", "stack": "cflinuxfs2", "position": 3, "enabled": true}
+						}
+					]
+				}`
+				updateResponse := `{
+					"metadata": {"guid": "bp-2-guid"},
+					"entity": {"name": "bp-2", "stack": "cflinuxfs2", "position": 2, "enabled": false}
+				}`
+				createResponse := `{
+					"metadata": {"guid": "bp-4-guid"},
+					"entity": {"name": "bp-4", "stack": "cflinuxfs2", "position": 3, "enabled": true}
+				}`
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, listResponse, http.Header{"X-Cf-Warnings": {"list warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodDelete, "/v2/buildpacks/bp-3-guid"),
+						RespondWith(http.StatusNoContent, nil, http.Header{"X-Cf-Warnings": {"delete warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/bp-2-guid"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "bp-2",
+							"stack":    "cflinuxfs2",
+							"position": 2,
+							"enabled":  false,
+							"locked":   false,
+						}),
+						RespondWith(http.StatusCreated, updateResponse, http.Header{"X-Cf-Warnings": {"update warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/v2/buildpacks"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "bp-4",
+							"stack":    "cflinuxfs2",
+							"position": 3,
+							"enabled":  true,
+						}),
+						RespondWith(http.StatusCreated, createResponse, http.Header{"X-Cf-Warnings": {"create warning"}}),
+					),
+				)
+			})
+
+			It("applies the minimal changes and reports them", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("list warning", "delete warning", "update warning", "create warning"))
+				Expect(result.HasChanges()).To(BeTrue())
+				Expect(result.Deleted).To(ConsistOf(Buildpack{GUID: "bp-3-guid", Name: "bp-3", Stack: "cflinuxfs2", Position: 3, Enabled: true}))
+				Expect(result.Updated).To(ConsistOf(Buildpack{GUID: "bp-2-guid", Name: "bp-2", Stack: "cflinuxfs2", Position: 2, Enabled: false}))
+				Expect(result.Created).To(ConsistOf(Buildpack{GUID: "bp-4-guid", Name: "bp-4", Stack: "cflinuxfs2", Position: 3, Enabled: true}))
+			})
+		})
+
+		Context("when the current buildpacks match the desired buildpacks", func() {
+			BeforeEach(func() {
+				listResponse := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "bp-1-guid"},
+							"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "bp-2-guid"},
+							"entity": {"name": "bp-2", "stack": "cflinuxfs2", "position": 2, "enabled": false}
+						},
+						{
+							"metadata": {"guid": "bp-4-guid"},
+							"entity": {"name": "bp-4", "stack": "cflinuxfs2", "position": 3, "enabled": true}
+						}
+					]
+				}`
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, listResponse, http.Header{"X-Cf-Warnings": {"list warning"}}),
+					),
+				)
+			})
+
+			It("makes no changes", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("list warning"))
+				Expect(result.HasChanges()).To(BeFalse())
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("when only the locked state of a buildpack differs", func() {
+			BeforeEach(func() {
+				desired = []Buildpack{
+					{Name: "bp-1", Stack: "cflinuxfs2", Position: 1, Enabled: true, Locked: true},
+				}
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{
+							"next_url": null,
+							"resources": [
+								{
+									"metadata": {"guid": "bp-1-guid"},
+									"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1, "enabled": true}
+								}
+							]
+						}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/bp-1-guid"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "bp-1",
+							"stack":    "cflinuxfs2",
+							"position": 1,
+							"enabled":  true,
+							"locked":   true,
+						}),
+						RespondWith(http.StatusCreated, `{
+							"metadata": {"guid": "bp-1-guid"},
+							"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1, "enabled": true, "locked": true}
+						}`),
+					),
+				)
+			})
+
+			It("locks the buildpack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(result.Updated).To(ConsistOf(Buildpack{GUID: "bp-1-guid", Name: "bp-1", Stack: "cflinuxfs2", Position: 1, Enabled: true, Locked: true}))
+			})
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package ccv2
+
+// BuildpackSetDiff describes the differences between the buildpacks of a
+// source and a target Cloud Controller. It can be marshaled to JSON for a
+// migration report.
+type BuildpackSetDiff struct {
+	// OnlyInSource are the source buildpacks that do not exist on the target.
+	OnlyInSource []Buildpack `json:"only_in_source"`
+
+	// OnlyInTarget are the target buildpacks that do not exist on the source.
+	OnlyInTarget []Buildpack `json:"only_in_target"`
+
+	// Differences are the settings that differ between a source buildpack and
+	// the target buildpack with the same name and stack.
+	Differences []BuildpackSettingDifference `json:"differences"`
+}
+
+// BuildpackSettingDifference describes a single setting that differs between
+// a source buildpack and its target counterpart.
+type BuildpackSettingDifference struct {
+	Name   string      `json:"name"`
+	Stack  string      `json:"stack,omitempty"`
+	Field  string      `json:"field"`
+	Source interface{} `json:"source"`
+	Target interface{} `json:"target"`
+}
+
+// HasDifferences returns true if any buildpack exists on only one side or
+// differs in its settings.
+func (diff BuildpackSetDiff) HasDifferences() bool {
+	return len(diff.OnlyInSource) > 0 || len(diff.OnlyInTarget) > 0 || len(diff.Differences) > 0
+}
+
+// DiffBuildpackSets compares the client's buildpacks, as the source, with the
+// buildpacks of the other client, as the target. The warnings of both
+// listings are returned.
+func (client *Client) DiffBuildpackSets(other *Client) (BuildpackSetDiff, Warnings, error) {
+	source, warnings, err := client.GetBuildpacks()
+	if err != nil {
+		return BuildpackSetDiff{}, warnings, err
+	}
+
+	target, targetWarnings, err := other.GetBuildpacks()
+	warnings = append(warnings, targetWarnings...)
+	if err != nil {
+		return BuildpackSetDiff{}, warnings, err
+	}
+
+	return DiffBuildpacks(source, target), warnings, nil
+}
+
+// DiffBuildpacks compares two sets of buildpacks, matching them by name and
+// stack. GUIDs are not compared since they differ between Cloud Controllers.
+func DiffBuildpacks(source []Buildpack, target []Buildpack) BuildpackSetDiff {
+	diff := BuildpackSetDiff{
+		OnlyInSource: []Buildpack{},
+		OnlyInTarget: []Buildpack{},
+		Differences:  []BuildpackSettingDifference{},
+	}
+
+	targetByKey := map[string]Buildpack{}
+	for _, buildpack := range target {
+		targetByKey[buildpackKey(buildpack)] = buildpack
+	}
+
+	sourceKeys := map[string]bool{}
+	for _, buildpack := range source {
+		sourceKeys[buildpackKey(buildpack)] = true
+
+		counterpart, exists := targetByKey[buildpackKey(buildpack)]
+		if !exists {
+			diff.OnlyInSource = append(diff.OnlyInSource, buildpack)
+			continue
+		}
+
+		diff.Differences = append(diff.Differences, buildpackSettingDifferences(buildpack, counterpart)...)
+	}
+
+	for _, buildpack := range target {
+		if !sourceKeys[buildpackKey(buildpack)] {
+			diff.OnlyInTarget = append(diff.OnlyInTarget, buildpack)
+		}
+	}
+
+	return diff
+}
+
+// buildpackSettingDifferences returns the settings that differ between source
+// and target.
+func buildpackSettingDifferences(source Buildpack, target Buildpack) []BuildpackSettingDifference {
+	var differences []BuildpackSettingDifference
+	add := func(field string, sourceValue interface{}, targetValue interface{}) {
+		if sourceValue != targetValue {
+			differences = append(differences, BuildpackSettingDifference{
+				Name:   source.Name,
+				Stack:  source.Stack,
+				Field:  field,
+				Source: sourceValue,
+				Target: targetValue,
+			})
+		}
+	}
+
+	add("position", source.Position, target.Position)
+	add("enabled", source.Enabled, target.Enabled)
+	add("locked", source.Locked, target.Locked)
+	add("filename", source.Filename, target.Filename)
+	return differences
+}
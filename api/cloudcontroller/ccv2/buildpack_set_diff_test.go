@@ -0,0 +1,147 @@
+package ccv2_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("BuildpackSetDiff", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("DiffBuildpackSets", func() {
+		var (
+			targetServer *Server
+			diff         BuildpackSetDiff
+			warnings     Warnings
+			executeErr   error
+		)
+
+		BeforeEach(func() {
+			targetServer = NewTLSServer()
+		})
+
+		AfterEach(func() {
+			targetServer.Close()
+		})
+
+		JustBeforeEach(func() {
+			diff, warnings, executeErr = client.DiffBuildpackSets(client.WithAPIRoot(targetServer.URL()))
+		})
+
+		Context("when both foundations list their buildpacks", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{
+							"next_url": null,
+							"resources": [
+								{
+									"metadata": {"guid": "source-bp-1-guid"},
+									"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1, "enabled": true, "filename": "bp-1-v1.zip"}
+								},
+								{
+									"metadata": {"guid": "source-bp-2-guid"},
+									"entity": {"name": "bp-2", "stack": "cflinuxfs2", "position": 2, "enabled": true}
+								}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"source-warning"}}),
+					),
+				)
+				targetServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{
+							"next_url": null,
+							"resources": [
+								{
+									"metadata": {"guid": "target-bp-1-guid"},
+									"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1, "enabled": false, "filename": "bp-1-v2.zip"}
+								},
+								{
+									"metadata": {"guid": "target-bp-2-guid"},
+									"entity": {"name": "bp-2", "stack": "cflinuxfs3", "position": 2, "enabled": true}
+								}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"target-warning"}}),
+					),
+				)
+			})
+
+			It("reports the buildpacks on one side only and the differing settings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("source-warning", "target-warning"))
+				Expect(diff.HasDifferences()).To(BeTrue())
+
+				Expect(diff.OnlyInSource).To(HaveLen(1))
+				Expect(diff.OnlyInSource[0].GUID).To(Equal("source-bp-2-guid"))
+				Expect(diff.OnlyInTarget).To(HaveLen(1))
+				Expect(diff.OnlyInTarget[0].GUID).To(Equal("target-bp-2-guid"))
+				Expect(diff.Differences).To(Equal([]BuildpackSettingDifference{
+					{Name: "bp-1", Stack: "cflinuxfs2", Field: "enabled", Source: true, Target: false},
+					{Name: "bp-1", Stack: "cflinuxfs2", Field: "filename", Source: "bp-1-v1.zip", Target: "bp-1-v2.zip"},
+				}))
+			})
+
+			It("can be marshaled to JSON", func() {
+				data, err := json.Marshal(diff)
+				Expect(err).ToNot(HaveOccurred())
+
+				var report map[string]interface{}
+				Expect(json.Unmarshal(data, &report)).To(Succeed())
+				Expect(report).To(HaveKey("only_in_source"))
+				Expect(report).To(HaveKey("only_in_target"))
+				Expect(report["differences"]).To(ContainElement(HaveKeyWithValue("field", "enabled")))
+			})
+		})
+
+		Context("when listing the target buildpacks fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`, http.Header{"X-Cf-Warnings": {"source-warning"}}),
+					),
+				)
+				targetServer.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusTeapot, `{"code": 10001, "description": "Some Error", "error_code": "CF-SomeError"}`, http.Header{"X-Cf-Warnings": {"target-warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and the warnings from both foundations", func() {
+				Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+					ResponseCode: http.StatusTeapot,
+					V2ErrorResponse: ccerror.V2ErrorResponse{
+						Code:        10001,
+						Description: "Some Error",
+						ErrorCode:   "CF-SomeError",
+					},
+				}))
+				Expect(warnings).To(ConsistOf("source-warning", "target-warning"))
+			})
+		})
+	})
+
+	Describe("DiffBuildpacks", func() {
+		It("reports no differences for matching buildpacks with different GUIDs", func() {
+			diff := DiffBuildpacks(
+				[]Buildpack{{GUID: "source-guid", Name: "bp-1", Stack: "cflinuxfs2", Position: 1, Enabled: true}},
+				[]Buildpack{{GUID: "target-guid", Name: "bp-1", Stack: "cflinuxfs2", Position: 1, Enabled: true}},
+			)
+			Expect(diff.HasDifferences()).To(BeFalse())
+		})
+	})
+})
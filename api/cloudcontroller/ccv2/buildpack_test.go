@@ -1,12 +1,19 @@
 package ccv2_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
@@ -15,6 +22,7 @@ import (
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/wrapper"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/ghttp"
 )
@@ -26,19 +34,255 @@ var _ = Describe("Buildpack", func() {
 		client = NewTestClient()
 	})
 
-	Describe("CreateBuildpack", func() {
+	Describe("ToCreateBody", func() {
+		It("sends the fields the Cloud Controller accepts on create", func() {
+			body, err := Buildpack{GUID: "some-guid", Name: "some-bp", Enabled: true, Locked: true, Stack: "some-stack"}.ToCreateBody()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"name": "some-bp", "enabled": true, "stack": "some-stack"}`))
+		})
+
+		It("always sends the name", func() {
+			body, err := Buildpack{}.ToCreateBody()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"name": "", "enabled": false}`))
+		})
+	})
+
+	Describe("ToUpdateBody", func() {
+		It("sends the fields the Cloud Controller accepts on update", func() {
+			body, err := Buildpack{GUID: "some-guid", Name: "some-bp", Enabled: true, Locked: true, Position: 2}.ToUpdateBody()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"name": "some-bp", "enabled": true, "locked": true, "position": 2}`))
+		})
+
+		It("sends a false locked so the buildpack can be unlocked", func() {
+			body, err := Buildpack{GUID: "some-guid", Enabled: false}.ToUpdateBody()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"enabled": false, "locked": false}`))
+		})
+	})
+
+	Describe("Clone", func() {
+		It("copies the Extra fields so changes do not affect the original", func() {
+			original := Buildpack{
+				GUID:  "some-bp-guid",
+				Name:  "some-bp-name",
+				Extra: map[string]json.RawMessage{"locked": json.RawMessage(`false`)},
+			}
+
+			clone := original.Clone()
+			Expect(clone).To(Equal(original))
+
+			clone.Extra["locked"][0] = 'F'
+			clone.Extra["filename"] = json.RawMessage(`"some-file.zip"`)
+			Expect(original.Extra).To(Equal(map[string]json.RawMessage{"locked": json.RawMessage(`false`)}))
+		})
+
+		It("keeps a nil Extra nil", func() {
+			Expect(Buildpack{Name: "some-bp-name"}.Clone().Extra).To(BeNil())
+		})
+	})
+
+	Describe("String", func() {
+		It("describes the buildpack with a truncated GUID", func() {
+			buildpack := Buildpack{
+				GUID:     "0f3c5a52-9bd1-4c4e-9f46-2e1a8b3f7d10",
+				Name:     "ruby_buildpack",
+				Position: 3,
+				Stack:    "cflinuxfs2",
+				Enabled:  true,
+				Locked:   true,
+			}
+			Expect(buildpack.String()).To(Equal("ruby_buildpack (pos=3, stack=cflinuxfs2, enabled=true, locked=true, guid=0f3c5a52...)"))
+		})
+
+		It("does not truncate short GUIDs", func() {
+			Expect(Buildpack{Name: "some-bp", GUID: "some-id"}.String()).To(Equal("some-bp (pos=0, stack=, enabled=false, locked=false, guid=some-id)"))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("returns nil for a valid buildpack", func() {
+			Expect(Buildpack{Name: "some_buildpack-1", Position: 1}.Validate()).To(Succeed())
+		})
+
+		It("returns every problem with an invalid buildpack", func() {
+			err := Buildpack{Name: "some buildpack!", Position: -1}.Validate()
+			Expect(err).To(MatchError(ccerror.BuildpackValidationError{
+				Problems: []string{
+					"name 'some buildpack!' must only contain alphanumeric characters, underscores, and dashes",
+					"position -1 must not be negative",
+				},
+			}))
+		})
+
+		It("returns an error for an empty name", func() {
+			err := Buildpack{}.Validate()
+			Expect(err).To(MatchError(ccerror.BuildpackValidationError{
+				Problems: []string{"name must not be empty"},
+			}))
+		})
+	})
+
+	Describe("Buildpacks.ValidateOrdering", func() {
+		It("returns nil when the positions are 1 to N", func() {
+			buildpacks := Buildpacks{
+				{Name: "bp-2", Position: 2},
+				{Name: "bp-1", Position: 1},
+				{Name: "bp-3", Position: 3},
+			}
+			Expect(buildpacks.ValidateOrdering()).To(Succeed())
+		})
+
+		It("returns nil for no buildpacks", func() {
+			Expect(Buildpacks{}.ValidateOrdering()).To(Succeed())
+		})
+
+		It("returns every gap, duplicate, and out of range position", func() {
+			buildpacks := Buildpacks{
+				{Name: "bp-1", Position: 1},
+				{Name: "bp-2", Position: 2},
+				{Name: "bp-3", Position: 2},
+				{Name: "bp-4", Position: 0},
+				{Name: "bp-5", Position: 7},
+			}
+			Expect(buildpacks.ValidateOrdering()).To(MatchError(ccerror.BuildpackOrderingError{
+				Problems: []string{
+					"buildpack 'bp-4' has position 0, which must be positive",
+					"buildpack 'bp-5' has position 7, which is greater than the number of buildpacks (5)",
+					"position 2 is shared by buildpacks 'bp-2', 'bp-3'",
+					"no buildpack has position 3, 4, 5",
+				},
+			}))
+		})
+	})
+
+	Describe("UnmarshalJSON", func() {
+		It("decodes known entity fields and keeps unknown ones in Extra", func() {
+			var buildpack Buildpack
+			err := json.Unmarshal([]byte(`{
+				"metadata": {"guid": "some-bp-guid", "created_at": "2016-06-08T16:41:45Z", "updated_at": "2016-06-09T10:00:00Z"},
+				"entity": {
+					"name": "some-bp-name",
+					"position": 2,
+					"enabled": true,
+					"stack": "some-stack",
+					"locked": true,
+					"filename": "some-file.zip",
+					"some_future_field": "some-value"
+				}
+			}`), &buildpack)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(buildpack.GUID).To(Equal("some-bp-guid"))
+			Expect(buildpack.Name).To(Equal("some-bp-name"))
+			Expect(buildpack.Position).To(Equal(2))
+			Expect(buildpack.Enabled).To(BeTrue())
+			Expect(buildpack.Stack).To(Equal("some-stack"))
+			Expect(buildpack.Locked).To(BeTrue())
+			Expect(buildpack.Filename).To(Equal("some-file.zip"))
+			Expect(buildpack.CreatedAt).To(Equal(time.Date(2016, 6, 8, 16, 41, 45, 0, time.UTC)))
+			Expect(buildpack.UpdatedAt).To(Equal(time.Date(2016, 6, 9, 10, 0, 0, 0, time.UTC)))
+			Expect(buildpack.Extra).To(Equal(map[string]json.RawMessage{
+				"some_future_field": json.RawMessage(`"some-value"`),
+			}))
+		})
+
+		It("leaves Extra nil when there are no unknown fields", func() {
+			var buildpack Buildpack
+			err := json.Unmarshal([]byte(`{"metadata": {"guid": "some-bp-guid"}, "entity": {"name": "some-bp-name"}}`), &buildpack)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildpack.Extra).To(BeNil())
+		})
+	})
+
+	Describe("BulkReorderBuildpacks", func() {
 		var (
-			buildpack  Buildpack
-			warnings   Warnings
-			executeErr error
+			orderedGUIDs []string
+			warnings     Warnings
+			executeErr   error
 		)
 
+		BeforeEach(func() {
+			response := `{
+				"next_url": null,
+				"resources": [
+					{"metadata": {"guid": "a-guid"}, "entity": {"name": "a", "stack": "cflinuxfs2", "position": 1, "enabled": true}},
+					{"metadata": {"guid": "b-guid"}, "entity": {"name": "b", "stack": "cflinuxfs2", "position": 2, "enabled": true}},
+					{"metadata": {"guid": "c-guid"}, "entity": {"name": "c", "stack": "cflinuxfs2", "position": 3, "enabled": true}}
+				]
+			}`
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+					RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"list warning"}}),
+				),
+			)
+		})
+
 		JustBeforeEach(func() {
-			buildpack, warnings, executeErr = client.CreateBuildpack(Buildpack{
+			warnings, executeErr = client.BulkReorderBuildpacks(orderedGUIDs)
+		})
+
+		Context("when buildpacks are out of order", func() {
+			BeforeEach(func() {
+				orderedGUIDs = []string{"c-guid", "a-guid"}
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/c-guid"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "c",
+							"stack":    "cflinuxfs2",
+							"position": 1,
+							"enabled":  true,
+							"locked":   false,
+						}),
+						RespondWith(http.StatusCreated, `{}`, http.Header{"X-Cf-Warnings": {"update c warning"}}),
+					),
+				)
+			})
+
+			It("updates only the buildpacks that are out of place", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("list warning", "update c warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+
+		Context("when a GUID has no buildpack", func() {
+			BeforeEach(func() {
+				orderedGUIDs = []string{"b-guid", "missing-guid"}
+			})
+
+			It("returns a ResourceNotFoundError without making changes", func() {
+				Expect(executeErr).To(MatchError(ccerror.ResourceNotFoundError{
+					Message: "The buildpack could not be found: missing-guid",
+				}))
+				Expect(warnings).To(ConsistOf("list warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("CreateBuildpack", func() {
+		var (
+			inputBuildpack Buildpack
+			buildpack      Buildpack
+			warnings       Warnings
+			executeErr     error
+		)
+
+		BeforeEach(func() {
+			inputBuildpack = Buildpack{
+				GUID:     "some-stray-guid",
 				Name:     "potato",
 				Position: 1,
 				Enabled:  true,
-			})
+			}
+		})
+
+		JustBeforeEach(func() {
+			buildpack, warnings, executeErr = client.CreateBuildpack(inputBuildpack)
 		})
 
 		Context("when the creation is successful", func() {
@@ -50,7 +294,7 @@ var _ = Describe("Buildpack", func() {
 					},
 					"entity": {
 						"name": "potato",
-						"stack": "null",
+						"stack": null,
 						"position": 1,
 						"enabled": true
 					}
@@ -83,6 +327,44 @@ var _ = Describe("Buildpack", func() {
 			})
 		})
 
+		Context("when buildpack validation is enabled", func() {
+			BeforeEach(func() {
+				client = NewTestClient(Config{ValidateBuildpacks: true})
+			})
+
+			Context("when the buildpack is invalid", func() {
+				BeforeEach(func() {
+					inputBuildpack = Buildpack{
+						Name:     "",
+						Position: -3,
+					}
+				})
+
+				It("returns a validation error without making a request", func() {
+					Expect(executeErr).To(MatchError(ccerror.BuildpackValidationError{
+						Problems: []string{
+							"name must not be empty",
+							"position -3 must not be negative",
+						},
+					}))
+					// Both requests are to /v2/info from creating the clients.
+					Expect(server.ReceivedRequests()).To(HaveLen(2))
+				})
+			})
+
+			Context("when the API version requires a stack", func() {
+				BeforeEach(func() {
+					client = NewClientWithCustomAPIVersion("99.0.0", Config{ValidateBuildpacks: true})
+				})
+
+				It("returns a validation error for the missing stack", func() {
+					Expect(executeErr).To(MatchError(ccerror.BuildpackValidationError{
+						Problems: []string{"stack must not be empty"},
+					}))
+				})
+			})
+		})
+
 		Context("when the create returns an error", func() {
 			BeforeEach(func() {
 				response := `
@@ -105,6 +387,202 @@ var _ = Describe("Buildpack", func() {
 				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
 			})
 		})
+
+		Context("when the create returns an array of errors", func() {
+			BeforeEach(func() {
+				response := `{
+					"errors": [
+						{"code": 290003, "title": "CF-BuildpackNameTaken", "detail": "The buildpack name is already in use: potato"},
+						{"code": 1001, "title": "CF-MessageParseError", "detail": "Position must be greater than 0"}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/v2/buildpacks"),
+						RespondWith(http.StatusUnprocessableEntity, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns every error in a MultiError and the warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.MultiError{
+					ResponseCode: http.StatusUnprocessableEntity,
+					Errors: []ccerror.V3Error{
+						{Code: 290003, Title: "CF-BuildpackNameTaken", Detail: "The buildpack name is already in use: potato"},
+						{Code: 1001, Title: "CF-MessageParseError", Detail: "Position must be greater than 0"},
+					},
+				}))
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+			})
+		})
+
+		Context("when a transport error occurs after warnings are received", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("some transport error")
+
+				wrapper := &wrapper.CustomWrapper{
+					CustomMake: func(connection cloudcontroller.Connection, request *cloudcontroller.Request, response *cloudcontroller.Response) error {
+						err := connection.Make(request, response)
+						if strings.Contains(request.URL.Path, "/v2/buildpacks") {
+							return expectedErr
+						}
+						return err
+					},
+				}
+
+				client = NewTestClient(Config{Wrappers: []ConnectionWrapper{wrapper}})
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/v2/buildpacks"),
+						RespondWith(http.StatusCreated, `{}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and the accumulated warnings", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("CreateBuildpackAtEnd", func() {
+		var (
+			buildpack  Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			buildpack, warnings, executeErr = client.CreateBuildpackAtEnd(Buildpack{
+				Name:     "potato",
+				Position: 1,
+				Enabled:  true,
+			})
+		})
+
+		Context("when buildpacks already exist", func() {
+			BeforeEach(func() {
+				listResponse := `{
+					"next_url": "/v2/buildpacks?order-by=position&order-direction=desc&page=2&results-per-page=1",
+					"resources": [
+						{
+							"metadata": {
+								"guid": "some-bp-guid"
+							},
+							"entity": {
+								"name": "some-bp-name",
+								"position": 7,
+								"enabled": true
+							}
+						}
+					]
+				}`
+				createResponse := `{
+					"metadata": {
+						"guid": "some-guid"
+					},
+					"entity": {
+						"name": "potato",
+						"position": 8,
+						"enabled": true
+					}
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "order-by=position&order-direction=desc&results-per-page=1"),
+						RespondWith(http.StatusOK, listResponse, http.Header{"X-Cf-Warnings": {"list warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/v2/buildpacks"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "potato",
+							"position": 8,
+							"enabled":  true,
+						}),
+						RespondWith(http.StatusCreated, createResponse, http.Header{"X-Cf-Warnings": {"create warning"}}),
+					),
+				)
+			})
+
+			It("creates the buildpack after the last position", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(buildpack).To(Equal(Buildpack{
+					GUID:     "some-guid",
+					Name:     "potato",
+					Enabled:  true,
+					Position: 8,
+				}))
+				Expect(warnings).To(ConsistOf("list warning", "create warning"))
+			})
+		})
+
+		Context("when there are no buildpacks", func() {
+			BeforeEach(func() {
+				createResponse := `{
+					"metadata": {
+						"guid": "some-guid"
+					},
+					"entity": {
+						"name": "potato",
+						"position": 1,
+						"enabled": true
+					}
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "order-by=position&order-direction=desc&results-per-page=1"),
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/v2/buildpacks"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "potato",
+							"position": 1,
+							"enabled":  true,
+						}),
+						RespondWith(http.StatusCreated, createResponse),
+					),
+				)
+			})
+
+			It("creates the buildpack at position 1", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(buildpack.Position).To(Equal(1))
+			})
+		})
+
+		Context("when listing the buildpacks errors", func() {
+			BeforeEach(func() {
+				response := `{
+					"code": 10001,
+					"description": "Some Error",
+					"error_code": "CF-SomeError"
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusTeapot, response, http.Header{"X-Cf-Warnings": {"list warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings without creating", func() {
+				Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+					ResponseCode: http.StatusTeapot,
+					V2ErrorResponse: ccerror.V2ErrorResponse{
+						Code:        10001,
+						Description: "Some Error",
+						ErrorCode:   "CF-SomeError",
+					},
+				}))
+				Expect(warnings).To(ConsistOf("list warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
 	})
 
 	Describe("UploadBuildpack", func() {
@@ -114,16 +592,25 @@ var _ = Describe("Buildpack", func() {
 			bpFile     io.Reader
 			bpFilePath string
 			bpContent  string
+			bpLength   int64
 		)
 
+		// drainBody reads the whole upload, so that responding cannot race with
+		// the client still writing the request body.
+		drainBody := func(_ http.ResponseWriter, req *http.Request) {
+			_, err := ioutil.ReadAll(req.Body)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
 		BeforeEach(func() {
 			bpContent = "some-content"
 			bpFile = strings.NewReader(bpContent)
 			bpFilePath = "some/fake-buildpack.zip"
+			bpLength = int64(len(bpContent))
 		})
 
 		JustBeforeEach(func() {
-			warnings, executeErr = client.UploadBuildpack("some-buildpack-guid", bpFilePath, bpFile, int64(len(bpContent)))
+			warnings, executeErr = client.UploadBuildpack("some-buildpack-guid", bpFilePath, bpFile, bpLength)
 		})
 
 		Context("when the upload is successful", func() {
@@ -151,6 +638,7 @@ var _ = Describe("Buildpack", func() {
 
 					Expect(buildpackPart.FormName()).To(Equal("buildpack"))
 					Expect(buildpackPart.FileName()).To(Equal("fake-buildpack.zip"))
+					Expect(buildpackPart.Header.Get("Content-Type")).To(Equal("application/zip"))
 
 					defer buildpackPart.Close()
 					partContents, err := ioutil.ReadAll(buildpackPart)
@@ -173,28 +661,451 @@ var _ = Describe("Buildpack", func() {
 			})
 		})
 
-		Context("when there is an error reading the buildpack", func() {
-			var (
-				fakeReader  *ccv2fakes.FakeReader
-				expectedErr error
-			)
-
+		Context("when a buildpack content type is configured", func() {
 			BeforeEach(func() {
-				expectedErr = errors.New("some read error")
-				fakeReader = new(ccv2fakes.FakeReader)
-				fakeReader.ReadReturns(0, expectedErr)
-				bpFile = fakeReader
-
-				server.AppendHandlers(
-					VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
-				)
-			})
+				client = NewTestClient(Config{BuildpackContentType: "application/octet-stream"})
+				bpFilePath = "some/buildpack-without-extension"
+
+				verifyBody := func(_ http.ResponseWriter, req *http.Request) {
+					defer req.Body.Close()
+					rawBody, err := ioutil.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(req.ContentLength).To(BeEquivalentTo(len(rawBody)))
+
+					contentType := req.Header.Get("Content-Type")
+					requestReader := multipart.NewReader(bytes.NewReader(rawBody), contentType[30:])
+					buildpackPart, err := requestReader.NextPart()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(buildpackPart.FileName()).To(Equal("buildpack-without-extension"))
+					Expect(buildpackPart.Header.Get("Content-Type")).To(Equal("application/octet-stream"))
+				}
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+						verifyBody,
+						RespondWith(http.StatusOK, `{}`),
+					),
+				)
+			})
+
+			It("uses it for the buildpack part", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the buildpack lock is checked", func() {
+			BeforeEach(func() {
+				client = NewTestClient(Config{CheckBuildpackLock: true})
+			})
+
+			Context("when the buildpack is locked", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodGet, "/v2/buildpacks/some-buildpack-guid"),
+							RespondWith(http.StatusOK, `{
+								"metadata": {"guid": "some-buildpack-guid"},
+								"entity": {"name": "some-bp-name", "locked": true}
+							}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+						),
+					)
+				})
+
+				It("returns a BuildpackLockedError without uploading", func() {
+					Expect(executeErr).To(MatchError(ccerror.BuildpackLockedError{Name: "some-bp-name"}))
+					Expect(warnings).To(ConsistOf("get warning"))
+					Expect(server.ReceivedRequests()).To(HaveLen(3))
+				})
+			})
+
+			Context("when the check is skipped", func() {
+				BeforeEach(func() {
+					client = client.WithoutBuildpackLockCheck()
+
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							drainBody,
+							RespondWith(http.StatusCreated, "{}"),
+						),
+					)
+				})
+
+				It("uploads without fetching the buildpack", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(server.ReceivedRequests()).To(HaveLen(3))
+				})
+			})
+
+			Context("when the buildpack is not locked", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodGet, "/v2/buildpacks/some-buildpack-guid"),
+							RespondWith(http.StatusOK, `{
+								"metadata": {"guid": "some-buildpack-guid"},
+								"entity": {"name": "some-bp-name", "locked": false}
+							}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+						),
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							drainBody,
+							RespondWith(http.StatusCreated, "{}", http.Header{"X-Cf-Warnings": {"upload warning"}}),
+						),
+					)
+				})
+
+				It("uploads the buildpack and returns all warnings", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf("get warning", "upload warning"))
+				})
+			})
+		})
+
+		Context("when required buildpack files are configured", func() {
+			BeforeEach(func() {
+				client = NewTestClient(Config{RequiredBuildpackFiles: DefaultRequiredBuildpackFiles})
+			})
+
+			Context("when the zip is missing required files", func() {
+				BeforeEach(func() {
+					zipContent := &bytes.Buffer{}
+					archive := zip.NewWriter(zipContent)
+					_, err := archive.Create("bin/detect")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(archive.Close()).To(Succeed())
+
+					bpFile = bytes.NewReader(zipContent.Bytes())
+					bpLength = int64(zipContent.Len())
+				})
+
+				It("returns an IncompleteBuildpackError without uploading", func() {
+					Expect(executeErr).To(MatchError(ccerror.IncompleteBuildpackError{MissingFiles: []string{"bin/compile", "bin/release"}}))
+					Expect(server.ReceivedRequests()).To(HaveLen(2))
+				})
+			})
+
+			Context("when the zip has every required file", func() {
+				BeforeEach(func() {
+					zipContent := &bytes.Buffer{}
+					archive := zip.NewWriter(zipContent)
+					for _, name := range []string{"bin/detect", "./bin/compile", "bin/release"} {
+						_, err := archive.Create(name)
+						Expect(err).ToNot(HaveOccurred())
+					}
+					Expect(archive.Close()).To(Succeed())
+
+					bpFile = bytes.NewReader(zipContent.Bytes())
+					bpLength = int64(zipContent.Len())
+
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							drainBody,
+							RespondWith(http.StatusCreated, "{}"),
+						),
+					)
+				})
+
+				It("uploads the buildpack", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(server.ReceivedRequests()).To(HaveLen(3))
+				})
+			})
+
+			Context("when the buildpack reader cannot be read at an offset", func() {
+				BeforeEach(func() {
+					bpFile = ioutil.NopCloser(strings.NewReader(bpContent))
+				})
+
+				It("returns an UncheckableBuildpackError without uploading", func() {
+					Expect(executeErr).To(MatchError(ccerror.UncheckableBuildpackError{Check: "required files"}))
+					Expect(server.ReceivedRequests()).To(HaveLen(2))
+				})
+			})
+
+			Context("when the buildpack length is unknown", func() {
+				BeforeEach(func() {
+					bpLength = -1
+				})
+
+				It("returns an UncheckableBuildpackError without uploading", func() {
+					Expect(executeErr).To(MatchError(ccerror.UncheckableBuildpackError{Check: "required files"}))
+					Expect(server.ReceivedRequests()).To(HaveLen(2))
+				})
+			})
+		})
+
+		Context("when buildpack archives are verified", func() {
+			var zipContent []byte
+
+			BeforeEach(func() {
+				client = NewTestClient(Config{VerifyBuildpackArchives: true})
+
+				buffer := &bytes.Buffer{}
+				archive := zip.NewWriter(buffer)
+				for _, name := range []string{"bin/detect", "bin/compile"} {
+					writer, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+					Expect(err).ToNot(HaveOccurred())
+					_, err = writer.Write([]byte("contents of " + name))
+					Expect(err).ToNot(HaveOccurred())
+				}
+				Expect(archive.Close()).To(Succeed())
+				zipContent = buffer.Bytes()
+
+				bpFile = bytes.NewReader(zipContent)
+				bpLength = int64(len(zipContent))
+			})
+
+			Context("when an entry does not match its checksum", func() {
+				BeforeEach(func() {
+					offset := bytes.Index(zipContent, []byte("contents of bin/compile"))
+					Expect(offset).To(BeNumerically(">", 0))
+					zipContent[offset] = 'C'
+				})
+
+				It("returns a CorruptBuildpackArchiveError naming the entry without uploading", func() {
+					Expect(executeErr).To(MatchError(ccerror.CorruptBuildpackArchiveError{Entries: []string{"bin/compile"}}))
+					Expect(server.ReceivedRequests()).To(HaveLen(2))
+				})
+			})
+
+			Context("when the zip is truncated", func() {
+				BeforeEach(func() {
+					bpFile = bytes.NewReader(zipContent[:len(zipContent)/2])
+					bpLength = int64(len(zipContent) / 2)
+				})
+
+				It("returns a CorruptBuildpackArchiveError without uploading", func() {
+					Expect(executeErr).To(MatchError(ccerror.CorruptBuildpackArchiveError{}))
+					Expect(server.ReceivedRequests()).To(HaveLen(2))
+				})
+			})
+
+			Context("when the zip cannot be read", func() {
+				BeforeEach(func() {
+					bpFile = failingReaderAt{err: errors.New("some read error")}
+				})
+
+				It("returns a CorruptBuildpackArchiveError without uploading", func() {
+					Expect(executeErr).To(MatchError(ccerror.CorruptBuildpackArchiveError{}))
+					Expect(server.ReceivedRequests()).To(HaveLen(2))
+				})
+			})
+
+			Context("when the buildpack reader cannot be read at an offset", func() {
+				BeforeEach(func() {
+					bpFile = ioutil.NopCloser(bytes.NewReader(zipContent))
+				})
+
+				It("returns an UncheckableBuildpackError without uploading", func() {
+					Expect(executeErr).To(MatchError(ccerror.UncheckableBuildpackError{Check: "corrupt entries"}))
+					Expect(server.ReceivedRequests()).To(HaveLen(2))
+				})
+			})
+
+			Context("when the buildpack length is unknown", func() {
+				BeforeEach(func() {
+					bpLength = -1
+				})
+
+				It("returns an UncheckableBuildpackError without uploading", func() {
+					Expect(executeErr).To(MatchError(ccerror.UncheckableBuildpackError{Check: "corrupt entries"}))
+					Expect(server.ReceivedRequests()).To(HaveLen(2))
+				})
+			})
+
+			Context("when the check is skipped", func() {
+				BeforeEach(func() {
+					bpFile = ioutil.NopCloser(bytes.NewReader(zipContent))
+					client = client.WithoutBuildpackArchiveCheck()
+
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							drainBody,
+							RespondWith(http.StatusCreated, "{}"),
+						),
+					)
+				})
+
+				It("uploads the buildpack", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("when the zip is intact", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							drainBody,
+							RespondWith(http.StatusCreated, "{}"),
+						),
+					)
+				})
+
+				It("uploads the buildpack", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when the buildpack length is unknown", func() {
+			BeforeEach(func() {
+				bpLength = -1
+
+				verifyChunkedBody := func(_ http.ResponseWriter, req *http.Request) {
+					Expect(req.TransferEncoding).To(ConsistOf("chunked"))
+
+					contentType := req.Header.Get("Content-Type")
+					defer req.Body.Close()
+					requestReader := multipart.NewReader(req.Body, contentType[30:])
+
+					buildpackPart, err := requestReader.NextPart()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(buildpackPart.FileName()).To(Equal("fake-buildpack.zip"))
+
+					partContents, err := ioutil.ReadAll(buildpackPart)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(partContents)).To(Equal(bpContent))
+				}
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+						verifyChunkedBody,
+						RespondWith(http.StatusOK, `{}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("streams the buildpack using chunked transfer encoding", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+
+		Context("when there is an error reading the buildpack", func() {
+			var (
+				fakeReader  *ccv2fakes.FakeReader
+				expectedErr error
+			)
+
+			BeforeEach(func() {
+				expectedErr = errors.New("some read error")
+				fakeReader = new(ccv2fakes.FakeReader)
+				fakeReader.ReadReturns(0, expectedErr)
+				bpFile = fakeReader
+
+				server.AppendHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+				)
+			})
 
 			It("returns the error", func() {
 				Expect(executeErr).To(MatchError(expectedErr))
 			})
 		})
 
+		Context("when reading the buildpack panics", func() {
+			BeforeEach(func() {
+				fakeReader := new(ccv2fakes.FakeReader)
+				fakeReader.ReadStub = func([]byte) (int, error) {
+					panic("some read panic")
+				}
+				bpFile = fakeReader
+
+				server.AppendHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+				)
+			})
+
+			It("returns an UploadPanicError", func() {
+				Expect(executeErr).To(MatchError(ccerror.UploadPanicError{Value: "some read panic"}))
+			})
+		})
+
+		Context("when the upload hangs past the upload timeout", func() {
+			var (
+				fakeReader   *ccv2fakes.FakeReader
+				readReturned chan struct{}
+			)
+
+			BeforeEach(func() {
+				client = NewTestClient(Config{UploadTimeout: 100 * time.Millisecond})
+
+				// The connection neither returns nor lets the buildpack read
+				// finish until the request is canceled, leaving the body writer
+				// blocked.
+				canceled := make(chan struct{})
+				fakeConnectionWrapper := new(ccv2fakes.FakeConnectionWrapper)
+				fakeConnectionWrapper.WrapReturns(fakeConnectionWrapper)
+				fakeConnectionWrapper.MakeStub = func(request *cloudcontroller.Request, _ *cloudcontroller.Response) error {
+					go io.Copy(ioutil.Discard, request.Body)
+					<-request.Context().Done()
+					close(canceled)
+					return request.Context().Err()
+				}
+				client.WrapConnection(fakeConnectionWrapper)
+
+				readReturned = make(chan struct{})
+				fakeReader = new(ccv2fakes.FakeReader)
+				fakeReader.ReadStub = func(p []byte) (int, error) {
+					defer close(readReturned)
+					<-canceled
+					return len(p), nil
+				}
+				bpFile = fakeReader
+				bpLength = -1
+			})
+
+			It("returns an UploadTimeoutError once the body writer has stopped", func() {
+				Expect(executeErr).To(MatchError(ccerror.UploadTimeoutError{Timeout: 100 * time.Millisecond}))
+				Eventually(readReturned).Should(BeClosed())
+				Expect(fakeReader.ReadCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the upload is canceled while the buildpack is being read", func() {
+			var fakeReader *ccv2fakes.FakeReader
+
+			BeforeEach(func() {
+				client = NewTestClient(Config{UploadTimeout: 100 * time.Millisecond})
+
+				// The connection keeps reading the body until the request is
+				// canceled, so only the context can stop the buildpack reads.
+				canceled := make(chan struct{})
+				fakeConnectionWrapper := new(ccv2fakes.FakeConnectionWrapper)
+				fakeConnectionWrapper.WrapReturns(fakeConnectionWrapper)
+				fakeConnectionWrapper.MakeStub = func(request *cloudcontroller.Request, _ *cloudcontroller.Response) error {
+					go io.Copy(ioutil.Discard, request.Body)
+					<-request.Context().Done()
+					close(canceled)
+					return request.Context().Err()
+				}
+				client.WrapConnection(fakeConnectionWrapper)
+
+				// The second read is in progress when the upload is canceled
+				// and completes afterwards.
+				fakeReader = new(ccv2fakes.FakeReader)
+				fakeReader.ReadStub = func(p []byte) (int, error) {
+					if fakeReader.ReadCallCount() > 1 {
+						<-canceled
+					}
+					return len(p), nil
+				}
+				bpFile = fakeReader
+				bpLength = -1
+			})
+
+			It("stops reading the buildpack once the upload is canceled", func() {
+				Expect(executeErr).To(MatchError(ccerror.UploadTimeoutError{Timeout: 100 * time.Millisecond}))
+				Expect(fakeReader.ReadCallCount()).To(Equal(2))
+			})
+		})
+
 		Context("when the upload returns an error", func() {
 			BeforeEach(func() {
 				response := `{
@@ -217,6 +1128,218 @@ var _ = Describe("Buildpack", func() {
 			})
 		})
 
+		Context("when the upload returns an array of errors", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+						drainBody,
+						RespondWith(http.StatusUnprocessableEntity, `{
+							"errors": [
+								{"code": 290002, "title": "CF-BuildpackInvalid", "detail": "Buildpack is not a zip"},
+								{"code": 290002, "title": "CF-BuildpackInvalid", "detail": "Buildpack is missing bin/detect"}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns every error in a MultiError and the warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.MultiError{
+					ResponseCode: http.StatusUnprocessableEntity,
+					Errors: []ccerror.V3Error{
+						{Code: 290002, Title: "CF-BuildpackInvalid", Detail: "Buildpack is not a zip"},
+						{Code: 290002, Title: "CF-BuildpackInvalid", Detail: "Buildpack is missing bin/detect"},
+					},
+				}))
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+			})
+		})
+
+		Context("when the buildpack exceeds the maximum size", func() {
+			Context("when the response includes the limit", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							RespondWith(http.StatusRequestEntityTooLarge, `{
+								"code": 10001,
+								"description": "Buildpack size exceeds the maximum of 1 GB",
+								"error_code": "CF-BuildpackTooLarge"
+							}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+						),
+					)
+				})
+
+				It("returns a BuildpackTooLargeError with the size and limit", func() {
+					Expect(executeErr).To(MatchError(ccerror.BuildpackTooLargeError{
+						Size:  bpLength,
+						Limit: 1024 * 1024 * 1024,
+					}))
+					Expect(warnings).To(ConsistOf("this is a warning"))
+				})
+			})
+
+			Context("when the response is not from the Cloud Controller", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							RespondWith(http.StatusRequestEntityTooLarge, "<html>413 Request Entity Too Large</html>"),
+						),
+					)
+				})
+
+				It("returns a BuildpackTooLargeError without a limit", func() {
+					Expect(executeErr).To(MatchError(ccerror.BuildpackTooLargeError{Size: bpLength}))
+				})
+			})
+		})
+
+		Context("when the buildpack is not ready to receive bits", func() {
+			var conflictResponse string
+
+			BeforeEach(func() {
+				conflictResponse = `{
+					"code": 10000,
+					"description": "The buildpack is not ready",
+					"error_code": "CF-Conflict"
+				}`
+
+				client = NewTestClient(Config{
+					BuildpackBitsNotReadyRetries:       2,
+					BuildpackBitsNotReadyRetryInterval: time.Millisecond,
+				})
+			})
+
+			Context("when a retry succeeds", func() {
+				BeforeEach(func() {
+					verifyBody := func(_ http.ResponseWriter, req *http.Request) {
+						defer req.Body.Close()
+						body, err := ioutil.ReadAll(req.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring(bpContent))
+					}
+
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							verifyBody,
+							RespondWith(http.StatusConflict, conflictResponse, http.Header{"X-Cf-Warnings": {"first warning"}}),
+						),
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							verifyBody,
+							RespondWith(http.StatusCreated, `{}`, http.Header{"X-Cf-Warnings": {"second warning"}}),
+						),
+					)
+				})
+
+				It("re-uploads the whole buildpack and returns all warnings", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf("first warning", "second warning"))
+				})
+			})
+
+			Context("when the conflict persists", func() {
+				BeforeEach(func() {
+					for i := 0; i < 3; i++ {
+						server.AppendHandlers(
+							CombineHandlers(
+								VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+								RespondWith(http.StatusConflict, conflictResponse, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+							),
+						)
+					}
+				})
+
+				It("gives up after the configured retries", func() {
+					Expect(executeErr).To(MatchError(ccerror.BuildpackBitsNotReadyError{
+						BuildpackGUID: "some-buildpack-guid",
+						Attempts:      3,
+					}))
+					Expect(warnings).To(ConsistOf("this is a warning", "this is a warning", "this is a warning"))
+				})
+			})
+
+			Context("when the buildpack reader cannot be rewound", func() {
+				BeforeEach(func() {
+					bpFile = ioutil.NopCloser(strings.NewReader(bpContent))
+
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							RespondWith(http.StatusConflict, conflictResponse),
+						),
+					)
+				})
+
+				It("returns the Cloud Controller's error without retrying", func() {
+					Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+						ResponseCode: http.StatusConflict,
+						V2ErrorResponse: ccerror.V2ErrorResponse{
+							Code:        10000,
+							Description: "The buildpack is not ready",
+							ErrorCode:   "CF-Conflict",
+						},
+					}))
+					Expect(server.ReceivedRequests()).To(HaveLen(3))
+				})
+			})
+
+			Context("when retries are disabled", func() {
+				BeforeEach(func() {
+					client = NewTestClient(Config{BuildpackBitsNotReadyRetries: -1})
+
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							RespondWith(http.StatusConflict, conflictResponse),
+						),
+					)
+				})
+
+				It("returns the Cloud Controller's error", func() {
+					Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+						ResponseCode: http.StatusConflict,
+						V2ErrorResponse: ccerror.V2ErrorResponse{
+							Code:        10000,
+							Description: "The buildpack is not ready",
+							ErrorCode:   "CF-Conflict",
+						},
+					}))
+				})
+			})
+
+			Context("when the Cloud Controller returns a different conflict", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+							RespondWith(http.StatusConflict, `{
+								"code": 290001,
+								"description": "The buildpack name is already in use: some-buildpack",
+								"error_code": "CF-BuildpackNameTaken"
+							}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+						),
+					)
+				})
+
+				It("returns the conflict without retrying", func() {
+					Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+						ResponseCode: http.StatusConflict,
+						V2ErrorResponse: ccerror.V2ErrorResponse{
+							Code:        290001,
+							Description: "The buildpack name is already in use: some-buildpack",
+							ErrorCode:   "CF-BuildpackNameTaken",
+						},
+					}))
+					Expect(warnings).To(ConsistOf("this is a warning"))
+					Expect(server.ReceivedRequests()).To(HaveLen(3))
+				})
+			})
+		})
+
 		Context("when a retryable error occurs", func() {
 			BeforeEach(func() {
 				wrapper := &wrapper.CustomWrapper{
@@ -269,29 +1392,548 @@ var _ = Describe("Buildpack", func() {
 				Expect(executeErr).To(MatchError(expectedErr))
 			})
 		})
+
+		Context("when a transport error occurs after warnings are received", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("some transport error")
+
+				wrapper := &wrapper.CustomWrapper{
+					CustomMake: func(connection cloudcontroller.Connection, request *cloudcontroller.Request, response *cloudcontroller.Response) error {
+						err := connection.Make(request, response)
+						if strings.Contains(request.URL.Path, "/v2/buildpacks") {
+							return expectedErr
+						}
+						return err
+					},
+				}
+
+				client = NewTestClient(Config{Wrappers: []ConnectionWrapper{wrapper}})
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+						RespondWith(http.StatusOK, `{}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and the accumulated warnings", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
 	})
 
-	Describe("GetBuildpacks", func() {
-		var (
-			buildpacks []Buildpack
-			warnings   Warnings
-			executeErr error
+	Describe("UploadBuildpack Content-Length", func() {
+		DescribeTable("matches the size of the multipart body that is sent",
+			func(buildpackPath string, contentType string, metadata json.RawMessage) {
+				client = NewTestClient(Config{BuildpackContentType: contentType})
+				content := "some-buildpack-content"
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							body, err := ioutil.ReadAll(req.Body)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(req.ContentLength).To(BeEquivalentTo(len(body)))
+						},
+						RespondWith(http.StatusCreated, "{}"),
+					),
+				)
+
+				_, err := client.UploadBuildpackWithMetadata("some-buildpack-guid", buildpackPath, strings.NewReader(content), int64(len(content)), metadata)
+				Expect(err).ToNot(HaveOccurred())
+			},
+			Entry("a simple file name", "buildpack.zip", "", nil),
+			Entry("a file name in a directory", "some/dir/buildpack.zip", "", nil),
+			Entry("a file name with quotes and backslashes", `some "quoted" \\name.zip`, "", nil),
+			Entry("a file name with multi-byte characters", "caf\u00e9-buildpack.zip", "", nil),
+			Entry("a custom content type", "buildpack.zip", "application/octet-stream", nil),
+			Entry("metadata", "buildpack.zip", "", json.RawMessage(`{"checksum": "abc"}`)),
 		)
+	})
 
-		JustBeforeEach(func() {
-			bpName := Filter{
-				Type:     constant.NameFilter,
-				Operator: constant.EqualOperator,
-				Values:   []string{"some-bp-name"},
-			}
+	Describe("UploadBuildpackFromSizer", func() {
+		It("uploads the buildpack using the size reported by the reader", func() {
+			content := "some-buildpack-content"
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+					func(_ http.ResponseWriter, req *http.Request) {
+						Expect(req.ParseMultipartForm(1024)).To(Succeed())
+						file, _, err := req.FormFile("buildpack")
+						Expect(err).ToNot(HaveOccurred())
+						bits, err := ioutil.ReadAll(file)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(bits)).To(Equal(content))
+					},
+					RespondWith(http.StatusCreated, "{}", http.Header{"X-Cf-Warnings": {"upload-warning"}}),
+				),
+			)
 
-			buildpacks, warnings, executeErr = client.GetBuildpacks(bpName)
+			warnings, err := client.UploadBuildpackFromSizer("some-buildpack-guid", "buildpack.zip", strings.NewReader(content))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf("upload-warning"))
 		})
+	})
 
-		Context("when buildpacks are found", func() {
-			BeforeEach(func() {
-				response1 := `{
-					"next_url": "/v2/buildpacks?q=name:some-bp-name",
+	Describe("UploadBuildpackWithMetadata", func() {
+		It("sends the metadata field ahead of the buildpack bits with a matching Content-Length", func() {
+			bpContent := "some-content"
+
+			verifyHeaderAndBody := func(_ http.ResponseWriter, req *http.Request) {
+				contentType := req.Header.Get("Content-Type")
+				Expect(contentType).To(MatchRegexp("multipart/form-data; boundary=[\\w\\d]+"))
+
+				defer req.Body.Close()
+				rawBody, err := ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.ContentLength).To(BeEquivalentTo(len(rawBody)))
+
+				requestReader := multipart.NewReader(bytes.NewReader(rawBody), contentType[30:])
+
+				metadataPart, err := requestReader.NextPart()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadataPart.FormName()).To(Equal("metadata"))
+				metadataContents, err := ioutil.ReadAll(metadataPart)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadataContents).To(MatchJSON(`{"some-key": "some-value"}`))
+
+				buildpackPart, err := requestReader.NextPart()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildpackPart.FormName()).To(Equal("buildpack"))
+				partContents, err := ioutil.ReadAll(buildpackPart)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(partContents)).To(Equal(bpContent))
+			}
+
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+					verifyHeaderAndBody,
+					RespondWith(http.StatusOK, `{}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+				),
+			)
+
+			warnings, err := client.UploadBuildpackWithMetadata(
+				"some-buildpack-guid",
+				"some/fake-buildpack.zip",
+				strings.NewReader(bpContent),
+				int64(len(bpContent)),
+				json.RawMessage(`{"some-key": "some-value"}`),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf("this is a warning"))
+		})
+	})
+
+	DescribeTable("operations given an empty buildpack GUID",
+		func(operation func() error) {
+			Expect(operation()).To(MatchError(ccerror.EmptyBuildpackGUIDError{}))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		},
+		Entry("DeleteBuildpack", func() error {
+			_, err := client.DeleteBuildpack("")
+			return err
+		}),
+		Entry("GetBuildpack", func() error {
+			_, _, err := client.GetBuildpack("")
+			return err
+		}),
+		Entry("HeadBuildpackBits", func() error {
+			_, _, _, err := client.HeadBuildpackBits("")
+			return err
+		}),
+		Entry("RenameBuildpack", func() error {
+			_, _, err := client.RenameBuildpack("", "some-name")
+			return err
+		}),
+		Entry("UpdateBuildpack", func() error {
+			_, _, err := client.UpdateBuildpack(Buildpack{Name: "some-name"})
+			return err
+		}),
+		Entry("UploadBuildpack", func() error {
+			_, err := client.UploadBuildpack("", "some-bp.zip", strings.NewReader("some-content"), 12)
+			return err
+		}),
+		Entry("UploadPreparedBuildpack", func() error {
+			_, err := client.UploadPreparedBuildpack("", PreparedBuildpackUpload{})
+			return err
+		}),
+	)
+
+	DescribeTable("buildpack writes without admin scope",
+		func(method string, path string, write func() (Warnings, error)) {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(method, path),
+					RespondWith(http.StatusForbidden, `{
+						"code": 10003,
+						"description": "You are not authorized to perform the requested action",
+						"error_code": "CF-NotAuthorized"
+					}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+				),
+			)
+
+			warnings, err := write()
+			Expect(err).To(MatchError(ccerror.ForbiddenError{
+				Message: "Managing buildpacks requires the cloud_controller.admin scope: You are not authorized to perform the requested action",
+			}))
+			Expect(warnings).To(ConsistOf("this is a warning"))
+		},
+		Entry("CreateBuildpack", http.MethodPost, "/v2/buildpacks", func() (Warnings, error) {
+			_, warnings, err := client.CreateBuildpack(Buildpack{Name: "some-bp-name"})
+			return warnings, err
+		}),
+		Entry("UpdateBuildpack", http.MethodPut, "/v2/buildpacks/some-bp-guid", func() (Warnings, error) {
+			_, warnings, err := client.UpdateBuildpack(Buildpack{GUID: "some-bp-guid", Name: "some-bp-name"})
+			return warnings, err
+		}),
+		Entry("DeleteBuildpack", http.MethodDelete, "/v2/buildpacks/some-bp-guid", func() (Warnings, error) {
+			return client.DeleteBuildpack("some-bp-guid")
+		}),
+		Entry("RenameBuildpack", http.MethodPut, "/v2/buildpacks/some-bp-guid", func() (Warnings, error) {
+			_, warnings, err := client.RenameBuildpack("some-bp-guid", "new-name")
+			return warnings, err
+		}),
+	)
+
+	Describe("FindDuplicateBuildpackNames", func() {
+		var (
+			duplicates map[string][]Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			duplicates, warnings, executeErr = client.FindDuplicateBuildpackNames()
+		})
+
+		Context("when the listing succeeds", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{"metadata": {"guid": "ruby-1-guid"}, "entity": {"name": "ruby", "stack": "cflinuxfs2", "position": 1}},
+						{"metadata": {"guid": "ruby-2-guid"}, "entity": {"name": "ruby", "stack": "cflinuxfs2", "position": 2}},
+						{"metadata": {"guid": "ruby-3-guid"}, "entity": {"name": "ruby", "stack": "cflinuxfs3", "position": 3}},
+						{"metadata": {"guid": "go-1-guid"}, "entity": {"name": "go", "stack": "cflinuxfs2", "position": 4}},
+						{"metadata": {"guid": "go-2-guid"}, "entity": {"name": "go", "stack": "cflinuxfs3", "position": 5}}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns only the names duplicated on the same stack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(duplicates).To(HaveLen(1))
+				Expect(duplicates).To(HaveKey("ruby"))
+
+				var guids []string
+				for _, buildpack := range duplicates["ruby"] {
+					guids = append(guids, buildpack.GUID)
+				}
+				Expect(guids).To(ConsistOf("ruby-1-guid", "ruby-2-guid"))
+			})
+		})
+
+		Context("when the cloud controller returns an error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusTeapot, `{"code": 10001, "description": "Some Error", "error_code": "CF-SomeError"}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("GetBuildpack", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid"),
+					RespondWith(http.StatusOK, `{
+						"metadata": {"guid": "some-bp-guid"},
+						"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 1, "enabled": true}
+					}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+				),
+			)
+		})
+
+		It("returns the buildpack and warnings", func() {
+			buildpack, warnings, err := client.GetBuildpack("some-bp-guid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf("this is a warning"))
+			Expect(buildpack).To(Equal(Buildpack{
+				GUID:     "some-bp-guid",
+				Name:     "some-bp-name",
+				Stack:    "cflinuxfs2",
+				Position: 1,
+				Enabled:  true,
+			}))
+		})
+	})
+
+	Describe("GetBuildpackByNameAndStack", func() {
+		const (
+			composedName   = "caf\u00e9_buildpack"
+			decomposedName = "cafe\u0301_buildpack"
+		)
+
+		DescribeTable("matching names regardless of normalization form",
+			func(requestedName string, storedName string) {
+				response := fmt.Sprintf(`{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "some-bp-guid"},
+							"entity": {"name": %q, "stack": "cflinuxfs2", "position": 1, "enabled": true}
+						}
+					]
+				}`, storedName)
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							Expect(req.URL.Query()["q"]).To(ConsistOf(
+								fmt.Sprintf("name IN %s,%s", composedName, decomposedName),
+								"stack:cflinuxfs2",
+							))
+						},
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+
+				buildpack, warnings, executeErr := client.GetBuildpackByNameAndStack(requestedName, "cflinuxfs2")
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(buildpack.GUID).To(Equal("some-bp-guid"))
+			},
+			Entry("NFC name stored as NFC", composedName, composedName),
+			Entry("NFC name stored as NFD", composedName, decomposedName),
+			Entry("NFD name stored as NFC", decomposedName, composedName),
+			Entry("NFD name stored as NFD", decomposedName, decomposedName),
+		)
+
+		Context("when the name has a single normalization form", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name&q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, `{"resources": []}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns a BuildpackNotFoundError when no buildpack matches", func() {
+				_, warnings, executeErr := client.GetBuildpackByNameAndStack("some-bp-name", "cflinuxfs2")
+				Expect(executeErr).To(MatchError(ccerror.BuildpackNotFoundError{Name: "some-bp-name", Stack: "cflinuxfs2"}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("GetBuildpackByPosition", func() {
+		var (
+			buildpack  Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			buildpack, warnings, executeErr = client.GetBuildpackByPosition(2, "cflinuxfs2")
+		})
+
+		Context("when one buildpack occupies the position", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "some-bp-guid1"},
+							"entity": {"name": "some-bp-name1", "stack": "cflinuxfs2", "position": 1, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "some-bp-guid2"},
+							"entity": {"name": "some-bp-name2", "stack": "cflinuxfs2", "position": 2, "enabled": true}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the buildpack and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(buildpack.GUID).To(Equal("some-bp-guid2"))
+			})
+		})
+
+		Context("when no buildpack occupies the position", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, `{"resources": []}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns a BuildpackNotFoundError and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.BuildpackNotFoundError{Stack: "cflinuxfs2", Position: 2}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+
+		Context("when several buildpacks occupy the position", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "some-bp-guid1"},
+							"entity": {"name": "some-bp-name1", "stack": "cflinuxfs2", "position": 2, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "some-bp-guid2"},
+							"entity": {"name": "some-bp-name2", "stack": "cflinuxfs2", "position": 2, "enabled": true}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, response),
+					),
+				)
+			})
+
+			It("returns a DuplicatePositionError naming every GUID", func() {
+				Expect(executeErr).To(MatchError(ccerror.DuplicatePositionError{
+					Position: 2,
+					Stack:    "cflinuxfs2",
+					GUIDs:    []string{"some-bp-guid1", "some-bp-guid2"},
+				}))
+			})
+		})
+	})
+
+	Describe("GetBuildpackGUID", func() {
+		var (
+			guid       string
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			guid, warnings, executeErr = client.GetBuildpackGUID("some-bp-name", "cflinuxfs2")
+		})
+
+		Context("when the buildpack exists", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "some-bp-guid"},
+							"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 1, "enabled": true}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name&q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the GUID and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(guid).To(Equal("some-bp-guid"))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+
+		Context("when the buildpack does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name&q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, `{"resources": []}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns a BuildpackNotFoundError and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.BuildpackNotFoundError{Name: "some-bp-name", Stack: "cflinuxfs2"}))
+				Expect(guid).To(BeEmpty())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("GetBuildpacks", func() {
+		var (
+			buildpacks []Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			bpName := Filter{
+				Type:     constant.NameFilter,
+				Operator: constant.EqualOperator,
+				Values:   []string{"some-bp-name"},
+			}
+
+			buildpacks, warnings, executeErr = client.GetBuildpacks(bpName)
+		})
+
+		Context("when a page exceeds the maximum buildpack response size", func() {
+			BeforeEach(func() {
+				client = NewTestClient(Config{MaxBuildpackResponseSize: 64})
+
+				response := fmt.Sprintf(`{"next_url": null, "resources": [], "padding": %q}`, strings.Repeat("a", 64))
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns a ResponseTooLargeError", func() {
+				Expect(executeErr).To(MatchError(ccerror.ResponseTooLargeError{Limit: 64}))
+				Expect(buildpacks).To(BeEmpty())
+			})
+		})
+
+		Context("when buildpacks are found", func() {
+			BeforeEach(func() {
+				response1 := `{
+					"next_url": "/v2/buildpacks?q=name:some-bp-name",
 					"resources": [
 						{
 							"metadata": {
@@ -336,81 +1978,1521 @@ var _ = Describe("Buildpack", func() {
 				server.AppendHandlers(
 					CombineHandlers(
 						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name"),
-						RespondWith(http.StatusOK, response1, http.Header{"X-Cf-Warnings": {"first warning"}}),
+						RespondWith(http.StatusOK, response1, http.Header{"X-Cf-Warnings": {"first warning"}}),
+					),
+				)
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name"),
+						RespondWith(http.StatusOK, response2, http.Header{"X-Cf-Warnings": {"second warning"}}),
+					),
+				)
+			})
+
+			It("returns the buildpacks", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(buildpacks).To(Equal([]Buildpack{
+					{
+						Name:     "some-bp-name1",
+						GUID:     "some-bp-guid1",
+						Enabled:  true,
+						Position: 2,
+					},
+					{
+						Name:     "some-bp-name2",
+						GUID:     "some-bp-guid2",
+						Enabled:  false,
+						Position: 3,
+					},
+					{
+						Name:     "some-bp-name3",
+						GUID:     "some-bp-guid3",
+						Enabled:  true,
+						Position: 4,
+					},
+				}))
+
+				Expect(warnings).To(ConsistOf(Warnings{"first warning", "second warning"}))
+			})
+		})
+
+		Context("when no buildpacks are found", func() {
+			BeforeEach(func() {
+				response := `{
+					"resources": []
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns an empty list", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+				Expect(buildpacks).To(HaveLen(0))
+			})
+		})
+
+		Context("when the token expires before a later page", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name"),
+						RespondWith(http.StatusOK, `{
+							"next_url": "/v2/buildpacks?q=name:some-bp-name&page=2",
+							"resources": [
+								{"metadata": {"guid": "some-bp-guid1"}, "entity": {"name": "some-bp-name1", "position": 1}}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"first warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name&page=2"),
+						RespondWith(http.StatusUnauthorized, `{
+							"code": 1000,
+							"description": "Invalid Auth Token",
+							"error_code": "CF-InvalidAuthToken"
+						}`, http.Header{"X-Cf-Warnings": {"second warning"}}),
+					),
+				)
+			})
+
+			It("returns an InvalidAuthTokenError and the warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.InvalidAuthTokenError{Message: "Invalid Auth Token"}))
+				Expect(warnings).To(ConsistOf("first warning", "second warning"))
+			})
+		})
+
+		Context("when the API responds with an error", func() {
+			BeforeEach(func() {
+				response := `{
+					"code": 10001,
+					"description": "Whoops",
+					"error_code": "CF-SomeError"
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name"),
+						RespondWith(http.StatusTeapot, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns warnings and the error", func() {
+				Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+					ResponseCode: http.StatusTeapot,
+					V2ErrorResponse: ccerror.V2ErrorResponse{
+						Code:        10001,
+						Description: "Whoops",
+						ErrorCode:   "CF-SomeError",
+					},
+				}))
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+			})
+		})
+	})
+
+	Describe("GetBuildpacksByGUIDs", func() {
+		var (
+			buildpacks []Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			buildpacks, warnings, executeErr = client.GetBuildpacksByGUIDs([]string{"bp-2-guid", "missing-guid", "bp-1-guid", "bp-2-guid"})
+		})
+
+		Context("when the buildpacks are fetched", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/bp-2-guid"),
+						RespondWith(http.StatusOK, `{
+							"metadata": {"guid": "bp-2-guid"},
+							"entity": {"name": "bp-2", "stack": "cflinuxfs2", "position": 2}
+						}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/missing-guid"),
+						RespondWith(http.StatusNotFound, `{
+							"code": 10000,
+							"description": "Unknown request",
+							"error_code": "CF-NotFound"
+						}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/bp-1-guid"),
+						RespondWith(http.StatusOK, `{
+							"metadata": {"guid": "bp-1-guid"},
+							"entity": {"name": "bp-1", "stack": "cflinuxfs2", "position": 1}
+						}`, http.Header{"X-Cf-Warnings": {"this is another warning"}}),
+					),
+				)
+			})
+
+			It("returns the existing buildpacks in order with deduplicated warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(Equal(Warnings{"this is a warning", "this is another warning"}))
+				Expect(buildpacks).To(Equal([]Buildpack{
+					{GUID: "bp-2-guid", Name: "bp-2", Stack: "cflinuxfs2", Position: 2},
+					{GUID: "bp-1-guid", Name: "bp-1", Stack: "cflinuxfs2", Position: 1},
+				}))
+				Expect(server.ReceivedRequests()).To(HaveLen(4))
+			})
+		})
+
+		Context("when fetching a buildpack fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/bp-2-guid"),
+						RespondWith(http.StatusTeapot, `{"code": 10001, "description": "Some Error", "error_code": "CF-SomeError"}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+					ResponseCode: http.StatusTeapot,
+					V2ErrorResponse: ccerror.V2ErrorResponse{
+						Code:        10001,
+						Description: "Some Error",
+						ErrorCode:   "CF-SomeError",
+					},
+				}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("GetBuildpacksByNamePrefix", func() {
+		var (
+			buildpacks []Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			buildpacks, warnings, executeErr = client.GetBuildpacksByNamePrefix("ruby")
+		})
+
+		Context("when listing the buildpacks succeeds", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "ruby-2-guid"},
+							"entity": {"name": "ruby_buildpack", "stack": "cflinuxfs3", "position": 1}
+						},
+						{
+							"metadata": {"guid": "go-guid"},
+							"entity": {"name": "go_buildpack", "stack": "cflinuxfs2", "position": 2}
+						},
+						{
+							"metadata": {"guid": "ruby-legacy-guid"},
+							"entity": {"name": "ruby-legacy", "stack": "cflinuxfs2", "position": 3}
+						},
+						{
+							"metadata": {"guid": "ruby-1-guid"},
+							"entity": {"name": "ruby_buildpack", "stack": "cflinuxfs2", "position": 4}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the matching buildpacks sorted by name and stack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+
+				var guids []string
+				for _, buildpack := range buildpacks {
+					guids = append(guids, buildpack.GUID)
+				}
+				Expect(guids).To(Equal([]string{"ruby-legacy-guid", "ruby-1-guid", "ruby-2-guid"}))
+			})
+		})
+
+		Context("when listing the buildpacks fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusTeapot, `{"code": 10001, "description": "Some Error", "error_code": "CF-SomeError"}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+					ResponseCode: http.StatusTeapot,
+					V2ErrorResponse: ccerror.V2ErrorResponse{
+						Code:        10001,
+						Description: "Some Error",
+						ErrorCode:   "CF-SomeError",
+					},
+				}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("GetBuildpacksByNames", func() {
+		var (
+			names      []string
+			buildpacks []Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			buildpacks, warnings, executeErr = client.GetBuildpacksByNames(names)
+		})
+
+		Context("when the names fit in a single query", func() {
+			BeforeEach(func() {
+				names = []string{"bp-1", "bp-2"}
+				response := `{
+					"next_url": null,
+					"resources": [
+						{"metadata": {"guid": "bp-1-guid"}, "entity": {"name": "bp-1"}},
+						{"metadata": {"guid": "bp-2-guid"}, "entity": {"name": "bp-2"}}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name%20IN%20bp-1,bp-2"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the buildpacks and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(buildpacks).To(ConsistOf(
+					Buildpack{GUID: "bp-1-guid", Name: "bp-1"},
+					Buildpack{GUID: "bp-2-guid", Name: "bp-2"},
+				))
+			})
+		})
+
+		Context("when the names need more than one query", func() {
+			BeforeEach(func() {
+				names = nil
+				for i := 0; i < 51; i++ {
+					names = append(names, fmt.Sprintf("bp-%d", i))
+				}
+
+				verifyBatchSize := func(size int) http.HandlerFunc {
+					return func(_ http.ResponseWriter, req *http.Request) {
+						query := strings.TrimPrefix(req.URL.Query().Get("q"), "name IN ")
+						Expect(strings.Split(query, ",")).To(HaveLen(size))
+					}
+				}
+				response := `{
+					"next_url": null,
+					"resources": [
+						{"metadata": {"guid": "bp-0-guid"}, "entity": {"name": "bp-0"}}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						verifyBatchSize(50),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						verifyBatchSize(1),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("batches the names and deduplicates buildpacks and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(buildpacks).To(ConsistOf(Buildpack{GUID: "bp-0-guid", Name: "bp-0"}))
+			})
+		})
+	})
+
+	Describe("GetBuildpacksWithOptions", func() {
+		var (
+			buildpacks []Buildpack
+			warnings   Warnings
+			executeErr error
+			options    GetBuildpacksOptions
+		)
+
+		BeforeEach(func() {
+			options = GetBuildpacksOptions{MaxPages: 2}
+
+			// The Cloud Controller keeps returning a next_url pointing to the
+			// same page.
+			response := `{
+				"next_url": "/v2/buildpacks?page=2",
+				"resources": [
+					{
+						"metadata": {"guid": "some-bp-guid"},
+						"entity": {"name": "some-bp-name", "position": 1, "enabled": true}
+					}
+				]
+			}`
+			for i := 0; i < 3; i++ {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			}
+		})
+
+		JustBeforeEach(func() {
+			buildpacks, warnings, executeErr = client.GetBuildpacksWithOptions(options)
+		})
+
+		Context("when more pages remain after MaxPages", func() {
+			It("stops and returns a PaginationLimitError with the buildpacks and warnings so far", func() {
+				Expect(executeErr).To(MatchError(ccerror.PaginationLimitError{
+					MaxPages: 2,
+					NextURL:  "/v2/buildpacks?page=2",
+				}))
+				Expect(buildpacks).To(HaveLen(2))
+				Expect(warnings).To(ConsistOf("this is a warning", "this is a warning"))
+			})
+		})
+
+		Context("when a Limit is set", func() {
+			BeforeEach(func() {
+				options = GetBuildpacksOptions{Limit: 2}
+			})
+
+			It("returns that many buildpacks without requesting further pages", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(buildpacks).To(HaveLen(2))
+				Expect(warnings).To(ConsistOf("this is a warning", "this is a warning"))
+			})
+		})
+
+		Context("when WarnOnPositionGaps is set", func() {
+			BeforeEach(func() {
+				options = GetBuildpacksOptions{WarnOnPositionGaps: true}
+				server.Reset()
+			})
+
+			Context("when positions are missing", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+							RespondWith(http.StatusOK, `{
+								"resources": [
+									{"metadata": {"guid": "guid-1"}, "entity": {"name": "bp-1", "position": 1}},
+									{"metadata": {"guid": "guid-2"}, "entity": {"name": "bp-2", "position": 2}},
+									{"metadata": {"guid": "guid-5"}, "entity": {"name": "bp-5", "position": 5}}
+								]
+							}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+						),
+					)
+				})
+
+				It("adds a warning naming the missing positions", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(buildpacks).To(HaveLen(3))
+					Expect(warnings).To(Equal(Warnings{
+						"this is a warning",
+						"No buildpack has position 3, 4. A previous reorder may have failed.",
+					}))
+				})
+			})
+
+			Context("when the positions are contiguous", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+							RespondWith(http.StatusOK, `{
+								"resources": [
+									{"metadata": {"guid": "guid-2"}, "entity": {"name": "bp-2", "position": 2}},
+									{"metadata": {"guid": "guid-1"}, "entity": {"name": "bp-1", "position": 1}}
+								]
+							}`),
+						),
+					)
+				})
+
+				It("does not add a warning", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when OnPageLinks is set", func() {
+			var links []PaginationLinks
+
+			BeforeEach(func() {
+				links = nil
+				options = GetBuildpacksOptions{
+					OnPageLinks: func(pageLinks PaginationLinks) {
+						links = append(links, pageLinks)
+					},
+				}
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{"next_url": null, "prev_url": "/v2/buildpacks?page=1", "resources": []}`),
+					),
+				)
+			})
+
+			It("reports the links of every page", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(links).To(HaveLen(4))
+				Expect(links[0]).To(Equal(PaginationLinks{NextURL: "/v2/buildpacks?page=2"}))
+				Expect(links[3]).To(Equal(PaginationLinks{PrevURL: "/v2/buildpacks?page=1"}))
+			})
+		})
+
+		Context("when DiscardWarnings is set", func() {
+			BeforeEach(func() {
+				options.DiscardWarnings = true
+			})
+
+			It("returns nil warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.PaginationLimitError{
+					MaxPages: 2,
+					NextURL:  "/v2/buildpacks?page=2",
+				}))
+				Expect(buildpacks).To(HaveLen(2))
+				Expect(warnings).To(BeNil())
+			})
+		})
+
+		Context("when OnWarning is set", func() {
+			var (
+				streamed      []string
+				requestCounts []int
+			)
+
+			BeforeEach(func() {
+				streamed = nil
+				requestCounts = nil
+				options.DiscardWarnings = true
+				options.OnWarning = func(warning string) {
+					streamed = append(streamed, warning)
+					requestCounts = append(requestCounts, len(server.ReceivedRequests()))
+				}
+			})
+
+			It("passes each warning on as its page is received", func() {
+				Expect(executeErr).To(HaveOccurred())
+				Expect(streamed).To(Equal([]string{"this is a warning", "this is a warning"}))
+				Expect(requestCounts[1]).To(Equal(requestCounts[0] + 1))
+				Expect(warnings).To(BeNil())
+			})
+		})
+
+		Context("when an order is requested", func() {
+			BeforeEach(func() {
+				options = GetBuildpacksOptions{
+					OrderBy:        constant.OrderByName,
+					OrderDirection: constant.DescendingOrder,
+				}
+				server.Reset()
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "order-by=name&order-direction=desc"),
+						RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`),
+					),
+				)
+			})
+
+			It("passes the order to the Cloud Controller", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the last page is within MaxPages", func() {
+			BeforeEach(func() {
+				options.MaxPages = 4
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`),
+					),
+				)
+			})
+
+			It("returns all the buildpacks", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(buildpacks).To(HaveLen(3))
+			})
+		})
+	})
+
+	Describe("GetBuildpacksWithStop", func() {
+		var (
+			stop       chan struct{}
+			buildpacks []Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+
+			response1 := `{
+				"next_url": "/v2/buildpacks?page=2",
+				"resources": [
+					{
+						"metadata": {"guid": "bp-1-guid"},
+						"entity": {"name": "bp-1", "position": 1, "enabled": true}
+					}
+				]
+			}`
+			response2 := `{
+				"next_url": null,
+				"resources": [
+					{
+						"metadata": {"guid": "bp-2-guid"},
+						"entity": {"name": "bp-2", "position": 2, "enabled": true}
+					}
+				]
+			}`
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name+IN+bp-1,bp-2"),
+					RespondWith(http.StatusOK, response1, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks", "page=2"),
+					RespondWith(http.StatusOK, response2, http.Header{"X-Cf-Warnings": {"this is another warning"}}),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			buildpacks, warnings, executeErr = client.GetBuildpacksWithStop(stop, Filter{
+				Type:     constant.NameFilter,
+				Operator: constant.InOperator,
+				Values:   []string{"bp-1", "bp-2"},
+			})
+		})
+
+		Context("when stop is not closed", func() {
+			It("returns the buildpacks from every page", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(buildpacks).To(HaveLen(2))
+				Expect(warnings).To(ConsistOf("this is a warning", "this is another warning"))
+			})
+		})
+
+		Context("when stop is closed while listing", func() {
+			BeforeEach(func() {
+				server.SetHandler(1, CombineHandlers(
+					func(_ http.ResponseWriter, _ *http.Request) {
+						close(stop)
+					},
+					server.GetHandler(1),
+				))
+			})
+
+			It("returns the buildpacks so far and a CancelledError", func() {
+				Expect(executeErr).To(MatchError(ccerror.CancelledError{}))
+				Expect(buildpacks).To(ConsistOf(Buildpack{GUID: "bp-1-guid", Name: "bp-1", Position: 1, Enabled: true}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("GetBuildpacks with created_at filters", func() {
+		var (
+			buildpacks []Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			response := `{
+				"next_url": null,
+				"resources": [
+					{
+						"metadata": {"guid": "bp-1-guid", "created_at": "2018-01-01T00:00:00Z"},
+						"entity": {"name": "bp-1", "position": 1, "enabled": true}
+					},
+					{
+						"metadata": {"guid": "bp-2-guid", "created_at": "2018-02-01T00:00:00Z"},
+						"entity": {"name": "bp-2", "position": 2, "enabled": true}
+					},
+					{
+						"metadata": {"guid": "bp-3-guid", "created_at": "2018-03-01T00:00:00Z"},
+						"entity": {"name": "bp-3", "position": 3, "enabled": true}
+					}
+				]
+			}`
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name+IN+bp-1,bp-2,bp-3"),
+					RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			buildpacks, warnings, executeErr = client.GetBuildpacks(
+				Filter{
+					Type:     constant.NameFilter,
+					Operator: constant.InOperator,
+					Values:   []string{"bp-1", "bp-2", "bp-3"},
+				},
+				CreatedAfterFilter(time.Date(2018, 1, 15, 0, 0, 0, 0, time.UTC)),
+				CreatedBeforeFilter(time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)),
+			)
+		})
+
+		It("only sends the other filters and returns the buildpacks created in the window", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf("this is a warning"))
+			Expect(buildpacks).To(ConsistOf(Buildpack{
+				GUID:      "bp-2-guid",
+				Name:      "bp-2",
+				Position:  2,
+				Enabled:   true,
+				CreatedAt: time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC),
+			}))
+		})
+	})
+
+	Describe("GetBuildpacks with other created_at operators", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks", ""),
+					RespondWith(http.StatusOK, `{
+						"next_url": null,
+						"resources": [
+							{"metadata": {"guid": "bp-1-guid", "created_at": "2018-01-01T00:00:00Z"}, "entity": {"name": "bp-1"}},
+							{"metadata": {"guid": "bp-2-guid", "created_at": "2018-02-01T00:00:00Z"}, "entity": {"name": "bp-2"}},
+							{"metadata": {"guid": "bp-3-guid", "created_at": "2018-03-01T00:00:00Z"}, "entity": {"name": "bp-3"}}
+						]
+					}`),
+				),
+			)
+		})
+
+		It("matches any of the values of an IN filter", func() {
+			buildpacks, _, err := client.GetBuildpacks(Filter{
+				Type:     constant.CreatedAtFilter,
+				Operator: constant.InOperator,
+				Values:   []string{"2018-01-01T00:00:00Z", "2018-03-01T00:00:00Z"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildpacks).To(HaveLen(2))
+			Expect(buildpacks[0].GUID).To(Equal("bp-1-guid"))
+			Expect(buildpacks[1].GUID).To(Equal("bp-3-guid"))
+		})
+
+		It("returns an UnsupportedFilterOperatorError for other operators without sending a request", func() {
+			_, _, err := client.GetBuildpacks(Filter{
+				Type:     constant.CreatedAtFilter,
+				Operator: constant.FilterOperator(">="),
+				Values:   []string{"2018-01-01T00:00:00Z"},
+			})
+			Expect(err).To(MatchError(ccerror.UnsupportedFilterOperatorError{FilterType: "created_at", Operator: ">="}))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("DeleteBuildpack", func() {
+		var (
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			warnings, executeErr = client.DeleteBuildpack("some-bp-guid")
+		})
+
+		Context("when the delete is successful", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodDelete, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusNoContent, nil, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("deletes the buildpack and returns warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+
+		Context("when the buildpack does not exist", func() {
+			BeforeEach(func() {
+				response := `{
+					"code": 10000,
+					"description": "The buildpack could not be found: some-bp-guid",
+					"error_code": "CF-BuildpackNotFound"
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodDelete, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusNotFound, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.ResourceNotFoundError{Message: "The buildpack could not be found: some-bp-guid"}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("DeleteBuildpackSafe", func() {
+		var (
+			force      bool
+			warnings   Warnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			force = false
+		})
+
+		JustBeforeEach(func() {
+			warnings, executeErr = client.DeleteBuildpackSafe("some-bp-guid", force)
+		})
+
+		Context("when no app uses the buildpack", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusOK, `{"metadata": {"guid": "some-bp-guid"}, "entity": {"name": "some-bp-name"}}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/apps"),
+						RespondWith(http.StatusOK, `{
+							"resources": [
+								{"metadata": {"guid": "app-guid"}, "entity": {"name": "some-app", "buildpack": "other-bp-name"}}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"apps warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodDelete, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusNoContent, nil, http.Header{"X-Cf-Warnings": {"delete warning"}}),
+					),
+				)
+			})
+
+			It("deletes the buildpack and returns all warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("get warning", "apps warning", "delete warning"))
+			})
+		})
+
+		Context("when apps use the buildpack", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusOK, `{"metadata": {"guid": "some-bp-guid"}, "entity": {"name": "some-bp-name"}}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/apps"),
+						RespondWith(http.StatusOK, `{
+							"resources": [
+								{"metadata": {"guid": "app-guid-1"}, "entity": {"name": "some-app", "buildpack": "some-bp-name"}},
+								{"metadata": {"guid": "app-guid-2"}, "entity": {"name": "other-app", "buildpack": null}}
+							]
+						}`),
+					),
+				)
+			})
+
+			It("returns a BuildpackInUseError without deleting", func() {
+				Expect(executeErr).To(MatchError(ccerror.BuildpackInUseError{
+					BuildpackName: "some-bp-name",
+					AppNames:      []string{"some-app"},
+				}))
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+
+		Context("when force is set", func() {
+			BeforeEach(func() {
+				force = true
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodDelete, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("deletes the buildpack without checking apps", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("HeadBuildpackBits", func() {
+		var (
+			exists     bool
+			size       int64
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			exists, size, warnings, executeErr = client.HeadBuildpackBits("some-bp-guid")
+		})
+
+		Context("when the bits exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodHead, "/v2/buildpacks/some-bp-guid/download"),
+						RespondWith(http.StatusOK, nil, http.Header{
+							"X-Cf-Warnings":  {"this is a warning"},
+							"Content-Length": {"1024"},
+						}),
+					),
+				)
+			})
+
+			It("returns that the bits exist, their size, and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(exists).To(BeTrue())
+				Expect(size).To(BeEquivalentTo(1024))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+
+		Context("when the bits do not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodHead, "/v2/buildpacks/some-bp-guid/download"),
+						RespondWith(http.StatusNotFound, nil, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns that the bits do not exist without an error", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(exists).To(BeFalse())
+				Expect(size).To(BeZero())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+
+		Context("when the API errors", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodHead, "/v2/buildpacks/some-bp-guid/download"),
+						RespondWith(http.StatusTeapot, nil, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.UnknownHTTPSourceError{StatusCode: http.StatusTeapot, RawResponse: []byte{}}))
+				Expect(exists).To(BeFalse())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("GetRecentBuildpacks", func() {
+		var (
+			limit      int
+			buildpacks []Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			buildpacks, warnings, executeErr = client.GetRecentBuildpacks(limit)
+		})
+
+		Context("when more buildpacks exist than the limit", func() {
+			BeforeEach(func() {
+				limit = 3
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "order-by=created_at&order-direction=desc&results-per-page=3"),
+						RespondWith(http.StatusOK, `{
+							"next_url": "/v2/buildpacks?order-by=created_at&order-direction=desc&results-per-page=3&page=2",
+							"resources": [
+								{"metadata": {"guid": "guid-5", "created_at": "2018-05-05T00:00:00Z"}, "entity": {"name": "bp-5"}},
+								{"metadata": {"guid": "guid-4", "created_at": "2018-05-04T00:00:00Z"}, "entity": {"name": "bp-4"}}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"page 1 warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "order-by=created_at&order-direction=desc&results-per-page=3&page=2"),
+						RespondWith(http.StatusOK, `{
+							"next_url": "/v2/buildpacks?order-by=created_at&order-direction=desc&results-per-page=3&page=3",
+							"resources": [
+								{"metadata": {"guid": "guid-3", "created_at": "2018-05-03T00:00:00Z"}, "entity": {"name": "bp-3"}},
+								{"metadata": {"guid": "guid-2", "created_at": "2018-05-02T00:00:00Z"}, "entity": {"name": "bp-2"}}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"page 2 warning"}}),
+					),
+				)
+			})
+
+			It("returns the newest buildpacks without requesting further pages", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(buildpacks).To(HaveLen(3))
+				Expect(buildpacks[0].GUID).To(Equal("guid-5"))
+				Expect(buildpacks[1].GUID).To(Equal("guid-4"))
+				Expect(buildpacks[2].GUID).To(Equal("guid-3"))
+				Expect(buildpacks[2].CreatedAt).To(Equal(time.Date(2018, 5, 3, 0, 0, 0, 0, time.UTC)))
+				Expect(warnings).To(ConsistOf("page 1 warning", "page 2 warning"))
+			})
+		})
+
+		Context("when the limit is larger than a page", func() {
+			BeforeEach(func() {
+				limit = 500
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "order-by=created_at&order-direction=desc&results-per-page=100"),
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("requests the largest page size", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(buildpacks).To(BeEmpty())
+			})
+		})
+
+		Context("when the limit is zero", func() {
+			BeforeEach(func() {
+				limit = 0
+			})
+
+			It("returns no buildpacks without making a request", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(buildpacks).To(BeEmpty())
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetBuildpacksMap", func() {
+		var (
+			buildpacksMap map[string]Buildpack
+			warnings      Warnings
+			executeErr    error
+		)
+
+		JustBeforeEach(func() {
+			buildpacksMap, warnings, executeErr = client.GetBuildpacksMap()
+		})
+
+		Context("when buildpacks are found", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "guid-1"},
+							"entity": {"name": "ruby_buildpack", "stack": "cflinuxfs2", "position": 1, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "guid-2"},
+							"entity": {"name": "ruby_buildpack", "stack": "cflinuxfs3", "position": 2, "enabled": true}
+						},
+						{
+							"metadata": {"guid": "guid-3"},
+							"entity": {"name": "go_buildpack", "stack": "cflinuxfs3", "position": 3, "enabled": true}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("keys unique names by name and colliding names by name@stack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(buildpacksMap).To(HaveLen(3))
+				Expect(buildpacksMap).To(HaveKey("go_buildpack"))
+				Expect(buildpacksMap["ruby_buildpack@cflinuxfs2"].GUID).To(Equal("guid-1"))
+				Expect(buildpacksMap["ruby_buildpack@cflinuxfs3"].GUID).To(Equal("guid-2"))
+				Expect(buildpacksMap).ToNot(HaveKey("ruby_buildpack"))
+			})
+		})
+	})
+
+	Describe("MustGetBuildpacks", func() {
+		var (
+			buildpacks []Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			buildpacks, warnings, executeErr = client.MustGetBuildpacks(
+				Filter{Type: constant.NameFilter, Operator: constant.EqualOperator, Values: []string{"some-bp-name"}},
+				Filter{Type: constant.StackFilter, Operator: constant.EqualOperator, Values: []string{"cflinuxfs2"}},
+			)
+		})
+
+		Context("when buildpacks match", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "some-bp-guid"},
+							"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 1}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name&q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the buildpacks and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(buildpacks).To(Equal([]Buildpack{
+					{GUID: "some-bp-guid", Name: "some-bp-name", Stack: "cflinuxfs2", Position: 1},
+				}))
+			})
+		})
+
+		Context("when no buildpacks match", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name&q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, `{"resources": []}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns a BuildpackNotFoundError and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.BuildpackNotFoundError{Name: "some-bp-name", Stack: "cflinuxfs2"}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("PingBuildpacksEndpoint", func() {
+		Context("when the endpoint is healthy", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "results-per-page=1"),
+						RespondWith(http.StatusOK, `{"resources": []}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("succeeds and returns warnings", func() {
+				warnings, err := client.PingBuildpacksEndpoint()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+
+		DescribeTable("classifying failures",
+			func(status int, body string, reason ccerror.PingFailureReason) {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "results-per-page=1"),
+						RespondWith(status, body),
+					),
+				)
+
+				_, err := client.PingBuildpacksEndpoint()
+				Expect(err).To(BeAssignableToTypeOf(ccerror.PingError{}))
+				Expect(err.(ccerror.PingError).Reason).To(Equal(reason))
+			},
+			Entry("unauthorized", http.StatusUnauthorized, `{"error_code": "CF-InvalidAuthToken", "description": "Invalid Auth Token"}`, ccerror.PingFailureAuth),
+			Entry("forbidden", http.StatusForbidden, `{"error_code": "CF-NotAuthorized", "description": "Not authorized"}`, ccerror.PingFailureAuth),
+			Entry("server error", http.StatusBadGateway, `bad gateway`, ccerror.PingFailureServer),
+			Entry("unexpected client error", http.StatusTeapot, `{"error_code": "CF-Teapot"}`, ccerror.PingFailureUnknown),
+		)
+	})
+
+	Describe("RenameBuildpack", func() {
+		var (
+			buildpack  Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			buildpack, warnings, executeErr = client.RenameBuildpack("some-bp-guid", "new-name")
+		})
+
+		Context("when the rename is successful", func() {
+			BeforeEach(func() {
+				response := `{
+					"metadata": {"guid": "some-bp-guid"},
+					"entity": {"name": "new-name", "stack": "cflinuxfs2", "position": 3, "enabled": true}
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid"),
+						VerifyJSON(`{"name": "new-name"}`),
+						RespondWith(http.StatusCreated, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("sends only the name and returns the updated buildpack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(buildpack).To(Equal(Buildpack{
+					GUID:     "some-bp-guid",
+					Name:     "new-name",
+					Stack:    "cflinuxfs2",
+					Position: 3,
+					Enabled:  true,
+				}))
+			})
+		})
+
+		Context("when the new name is taken on the same stack", func() {
+			BeforeEach(func() {
+				response := `{
+					"code": 290001,
+					"description": "The buildpack name new-name is already in use for the stack cflinuxfs2",
+					"error_code": "CF-BuildpackNameStackTaken"
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusUnprocessableEntity, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns a BuildpackAlreadyExistsError and warnings", func() {
+				Expect(executeErr).To(MatchError(ccerror.BuildpackAlreadyExistsError{
+					Message: "The buildpack name new-name is already in use for the stack cflinuxfs2",
+				}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("RetryBuildpackUpload", func() {
+		var (
+			tempDir       string
+			buildpackPath string
+			warnings      Warnings
+			executeErr    error
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = ioutil.TempDir("", "retry-buildpack-upload-test")
+			Expect(err).ToNot(HaveOccurred())
+
+			buildpackPath = filepath.Join(tempDir, "some-buildpack.zip")
+			err = ioutil.WriteFile(buildpackPath, []byte("some-content"), 0600)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(tempDir)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			warnings, executeErr = client.RetryBuildpackUpload("some-bp-name", "cflinuxfs2", buildpackPath)
+		})
+
+		Context("when the buildpack exists", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {"guid": "some-bp-guid"},
+							"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 1}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name&q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							body, err := ioutil.ReadAll(req.Body)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(string(body)).To(ContainSubstring("some-content"))
+						},
+						RespondWith(http.StatusCreated, "{}", http.Header{"X-Cf-Warnings": {"upload warning"}}),
+					),
+				)
+			})
+
+			It("uploads the bits to the existing buildpack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("get warning", "upload warning"))
+			})
+		})
+
+		Context("when the buildpack does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{"resources": []}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+				)
+			})
+
+			It("returns a BuildpackNotFoundError without uploading", func() {
+				Expect(executeErr).To(MatchError(ccerror.BuildpackNotFoundError{Name: "some-bp-name", Stack: "cflinuxfs2"}))
+				Expect(warnings).To(ConsistOf("get warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("when the buildpack file does not exist", func() {
+			BeforeEach(func() {
+				buildpackPath = filepath.Join(tempDir, "missing.zip")
+			})
+
+			It("returns the error without making requests", func() {
+				Expect(os.IsNotExist(executeErr)).To(BeTrue())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("SetBuildpackOrder", func() {
+		var (
+			orderedNames []string
+			warnings     Warnings
+			executeErr   error
+		)
+
+		BeforeEach(func() {
+			response := `{
+				"next_url": null,
+				"resources": [
+					{"metadata": {"guid": "a-guid"}, "entity": {"name": "a", "stack": "cflinuxfs2", "position": 1, "enabled": true}},
+					{"metadata": {"guid": "other-guid"}, "entity": {"name": "other", "stack": "windows2012R2", "position": 2, "enabled": true}},
+					{"metadata": {"guid": "b-guid"}, "entity": {"name": "b", "stack": "cflinuxfs2", "position": 3, "enabled": true}},
+					{"metadata": {"guid": "c-guid"}, "entity": {"name": "c", "stack": "cflinuxfs2", "position": 4, "enabled": true}}
+				]
+			}`
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+					RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"list warning"}}),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			warnings, executeErr = client.SetBuildpackOrder(orderedNames, "cflinuxfs2")
+		})
+
+		Context("when buildpacks are out of order", func() {
+			BeforeEach(func() {
+				orderedNames = []string{"c", "b"}
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/c-guid"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "c",
+							"stack":    "cflinuxfs2",
+							"position": 1,
+							"enabled":  true,
+							"locked":   false,
+						}),
+						RespondWith(http.StatusCreated, `{}`, http.Header{"X-Cf-Warnings": {"update c warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/b-guid"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "b",
+							"stack":    "cflinuxfs2",
+							"position": 2,
+							"enabled":  true,
+							"locked":   false,
+						}),
+						RespondWith(http.StatusCreated, `{}`, http.Header{"X-Cf-Warnings": {"update b warning"}}),
+					),
+				)
+			})
+
+			It("moves only the buildpacks that are out of place, leaving unnamed ones last", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("list warning", "update c warning", "update b warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(4))
+			})
+		})
+
+		Context("when the buildpacks are already in order", func() {
+			BeforeEach(func() {
+				orderedNames = []string{"a", "b"}
+			})
+
+			It("makes no changes", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("when names do not resolve on the stack", func() {
+			BeforeEach(func() {
+				orderedNames = []string{"other", "a", "missing"}
+			})
+
+			It("returns an error naming them without making changes", func() {
+				Expect(executeErr).To(MatchError(ccerror.UnknownBuildpackNamesError{
+					Names: []string{"other", "missing"},
+					Stack: "cflinuxfs2",
+				}))
+				Expect(warnings).To(ConsistOf("list warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("StreamBuildpacks", func() {
+		Context("when the listing succeeds", func() {
+			BeforeEach(func() {
+				response1 := `{
+					"next_url": "/v2/buildpacks?q=name:some-bp-name&page=2",
+					"resources": [
+						{"metadata": {"guid": "bp-guid-1"}, "entity": {"name": "some-bp-name"}}
+					]
+				}`
+				response2 := `{
+					"next_url": null,
+					"resources": [
+						{"metadata": {"guid": "bp-guid-2"}, "entity": {"name": "some-bp-name"}}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name"),
+						RespondWith(http.StatusOK, response1),
 					),
-				)
-				server.AppendHandlers(
 					CombineHandlers(
-						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name"),
-						RespondWith(http.StatusOK, response2, http.Header{"X-Cf-Warnings": {"second warning"}}),
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name&page=2"),
+						RespondWith(http.StatusOK, response2),
 					),
 				)
 			})
 
-			It("returns the buildpacks", func() {
-				Expect(executeErr).ToNot(HaveOccurred())
-				Expect(buildpacks).To(Equal([]Buildpack{
-					{
-						Name:     "some-bp-name1",
-						GUID:     "some-bp-guid1",
-						Enabled:  true,
-						Position: 2,
-					},
-					{
-						Name:     "some-bp-name2",
-						GUID:     "some-bp-guid2",
-						Enabled:  false,
-						Position: 3,
-					},
-					{
-						Name:     "some-bp-name3",
-						GUID:     "some-bp-guid3",
-						Enabled:  true,
-						Position: 4,
-					},
-				}))
+			It("sends every buildpack and closes both channels", func() {
+				buildpacks, errs := client.StreamBuildpacks(Filter{
+					Type:     constant.NameFilter,
+					Operator: constant.EqualOperator,
+					Values:   []string{"some-bp-name"},
+				})
 
-				Expect(warnings).To(ConsistOf(Warnings{"first warning", "second warning"}))
+				var guids []string
+				for buildpack := range buildpacks {
+					guids = append(guids, buildpack.GUID)
+				}
+				Expect(guids).To(Equal([]string{"bp-guid-1", "bp-guid-2"}))
+
+				err, open := <-errs
+				Expect(err).ToNot(HaveOccurred())
+				Expect(open).To(BeFalse())
 			})
 		})
 
-		Context("when no buildpacks are found", func() {
+		Context("when filtering by creation time", func() {
 			BeforeEach(func() {
-				response := `{
-					"resources": []
-				}`
 				server.AppendHandlers(
 					CombineHandlers(
-						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name"),
-						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", ""),
+						RespondWith(http.StatusOK, `{
+							"next_url": null,
+							"resources": [
+								{"metadata": {"guid": "bp-guid-1", "created_at": "2018-01-01T00:00:00Z"}, "entity": {"name": "bp-1"}},
+								{"metadata": {"guid": "bp-guid-2", "created_at": "2018-02-01T00:00:00Z"}, "entity": {"name": "bp-2"}}
+							]
+						}`),
 					),
 				)
 			})
 
-			It("returns an empty list", func() {
-				Expect(executeErr).ToNot(HaveOccurred())
-				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
-				Expect(buildpacks).To(HaveLen(0))
+			It("does not send the created_at filter and only sends matching buildpacks", func() {
+				buildpacks, errs := client.StreamBuildpacks(CreatedAfterFilter(time.Date(2018, 1, 15, 0, 0, 0, 0, time.UTC)))
+
+				var guids []string
+				for buildpack := range buildpacks {
+					guids = append(guids, buildpack.GUID)
+				}
+				Expect(guids).To(Equal([]string{"bp-guid-2"}))
+				Expect(<-errs).ToNot(HaveOccurred())
 			})
 		})
 
-		Context("when the API responds with an error", func() {
+		Context("when a created_at filter uses an unsupported operator", func() {
+			It("sends an UnsupportedFilterOperatorError", func() {
+				buildpacks, errs := client.StreamBuildpacks(Filter{
+					Type:     constant.CreatedAtFilter,
+					Operator: constant.FilterOperator(">="),
+					Values:   []string{"2018-01-01T00:00:00Z"},
+				})
+				Eventually(buildpacks).Should(BeClosed())
+				Expect(<-errs).To(MatchError(ccerror.UnsupportedFilterOperatorError{FilterType: "created_at", Operator: ">="}))
+			})
+		})
+
+		Context("when a page fails", func() {
 			BeforeEach(func() {
-				response := `{
-					"code": 10001,
-					"description": "Whoops",
-					"error_code": "CF-SomeError"
-				}`
 				server.AppendHandlers(
 					CombineHandlers(
-						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name"),
-						RespondWith(http.StatusTeapot, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusTeapot, `{"code": 10001, "description": "Whoops", "error_code": "CF-SomeError"}`),
 					),
 				)
 			})
 
-			It("returns warnings and the error", func() {
-				Expect(executeErr).To(MatchError(ccerror.V2UnexpectedResponseError{
+			It("sends the error after closing the buildpack channel", func() {
+				buildpacks, errs := client.StreamBuildpacks()
+				Eventually(buildpacks).Should(BeClosed())
+				Expect(<-errs).To(MatchError(ccerror.V2UnexpectedResponseError{
 					ResponseCode: http.StatusTeapot,
 					V2ErrorResponse: ccerror.V2ErrorResponse{
 						Code:        10001,
@@ -418,7 +3500,6 @@ var _ = Describe("Buildpack", func() {
 						ErrorCode:   "CF-SomeError",
 					},
 				}))
-				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
 			})
 		})
 	})
@@ -461,6 +3542,12 @@ var _ = Describe("Buildpack", func() {
 				server.AppendHandlers(
 					CombineHandlers(
 						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "some-bp-name",
+							"position": 10,
+							"enabled":  true,
+							"locked":   false,
+						}),
 						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
 					),
 				)
@@ -535,5 +3622,385 @@ var _ = Describe("Buildpack", func() {
 				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
 			})
 		})
+
+		Context("when a transport error occurs after warnings are received", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("some transport error")
+
+				wrapper := &wrapper.CustomWrapper{
+					CustomMake: func(connection cloudcontroller.Connection, request *cloudcontroller.Request, response *cloudcontroller.Response) error {
+						err := connection.Make(request, response)
+						if strings.Contains(request.URL.Path, "/v2/buildpacks") {
+							return expectedErr
+						}
+						return err
+					},
+				}
+
+				client = NewTestClient(Config{Wrappers: []ConnectionWrapper{wrapper}})
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusOK, `{}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and the accumulated warnings", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("UpdateBuildpackIfUnmodified", func() {
+		var (
+			expectedUpdatedAt time.Time
+			updatedBuildpack  Buildpack
+			warnings          Warnings
+			executeErr        error
+		)
+
+		BeforeEach(func() {
+			expectedUpdatedAt = time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+		})
+
+		JustBeforeEach(func() {
+			updatedBuildpack, warnings, executeErr = client.UpdateBuildpackIfUnmodified(Buildpack{
+				GUID:     "some-bp-guid",
+				Name:     "some-bp",
+				Position: 2,
+				Enabled:  true,
+			}, expectedUpdatedAt)
+		})
+
+		Context("when the buildpack has not been modified", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusOK, `{"metadata": {"guid": "some-bp-guid", "updated_at": "2018-05-01T12:00:00Z"}, "entity": {"name": "some-bp", "position": 1}}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "some-bp",
+							"position": 2,
+							"enabled":  true,
+							"locked":   false,
+						}),
+						RespondWith(http.StatusCreated, `{"metadata": {"guid": "some-bp-guid"}, "entity": {"name": "some-bp", "position": 2, "enabled": true}}`, http.Header{"X-Cf-Warnings": {"update warning"}}),
+					),
+				)
+			})
+
+			It("updates the buildpack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("get warning", "update warning"))
+				Expect(updatedBuildpack.Position).To(Equal(2))
+			})
+		})
+
+		Context("when the buildpack was modified after it was read", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusOK, `{"metadata": {"guid": "some-bp-guid", "updated_at": "2018-05-01T12:30:00Z"}, "entity": {"name": "some-bp", "position": 1}}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+				)
+			})
+
+			It("returns a ConcurrentModificationError without updating", func() {
+				Expect(executeErr).To(MatchError(ccerror.ConcurrentModificationError{
+					GUID:              "some-bp-guid",
+					ExpectedUpdatedAt: expectedUpdatedAt,
+					ActualUpdatedAt:   time.Date(2018, 5, 1, 12, 30, 0, 0, time.UTC),
+				}))
+				Expect(warnings).To(ConsistOf("get warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("when the buildpack was never updated and no time is expected", func() {
+			BeforeEach(func() {
+				expectedUpdatedAt = time.Time{}
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusOK, `{"metadata": {"guid": "some-bp-guid", "updated_at": null}, "entity": {"name": "some-bp", "position": 1}}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusCreated, `{"metadata": {"guid": "some-bp-guid"}, "entity": {"name": "some-bp", "position": 2, "enabled": true}}`),
+					),
+				)
+			})
+
+			It("updates the buildpack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+	})
+
+	Describe("UpsertBuildpack", func() {
+		var (
+			desired    Buildpack
+			buildpack  Buildpack
+			created    bool
+			warnings   Warnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			desired = Buildpack{Name: "some-bp-name", Stack: "cflinuxfs2", Position: 2, Enabled: true}
+		})
+
+		JustBeforeEach(func() {
+			buildpack, created, warnings, executeErr = client.UpsertBuildpack(desired)
+		})
+
+		Context("when the buildpack does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=name:some-bp-name&q=stack:cflinuxfs2"),
+						RespondWith(http.StatusOK, `{"resources": []}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/v2/buildpacks"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "some-bp-name",
+							"stack":    "cflinuxfs2",
+							"position": 2,
+							"enabled":  true,
+						}),
+						RespondWith(http.StatusCreated, `{
+							"metadata": {"guid": "some-bp-guid"},
+							"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 2, "enabled": true}
+						}`, http.Header{"X-Cf-Warnings": {"create warning"}}),
+					),
+				)
+			})
+
+			It("creates the buildpack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+				Expect(buildpack.GUID).To(Equal("some-bp-guid"))
+				Expect(warnings).To(ConsistOf("get warning", "create warning"))
+			})
+		})
+
+		Context("when the buildpack exists with the same settings", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{
+							"resources": [
+								{
+									"metadata": {"guid": "some-bp-guid"},
+									"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 2, "enabled": true}
+								}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+				)
+			})
+
+			It("returns the existing buildpack without changing it", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(created).To(BeFalse())
+				Expect(buildpack.GUID).To(Equal("some-bp-guid"))
+				Expect(warnings).To(ConsistOf("get warning"))
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("when the buildpack exists with different settings", func() {
+			BeforeEach(func() {
+				desired.Position = 0
+				desired.Enabled = false
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{
+							"resources": [
+								{
+									"metadata": {"guid": "some-bp-guid"},
+									"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 5, "enabled": true}
+								}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"get warning"}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "some-bp-name",
+							"stack":    "cflinuxfs2",
+							"position": 5,
+							"enabled":  false,
+							"locked":   false,
+						}),
+						RespondWith(http.StatusCreated, `{
+							"metadata": {"guid": "some-bp-guid"},
+							"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 5, "enabled": false}
+						}`, http.Header{"X-Cf-Warnings": {"update warning"}}),
+					),
+				)
+			})
+
+			It("updates the changed settings and keeps the position", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(created).To(BeFalse())
+				Expect(buildpack.Enabled).To(BeFalse())
+				Expect(warnings).To(ConsistOf("get warning", "update warning"))
+			})
+		})
+
+		Context("when only the locked state of the buildpack differs", func() {
+			BeforeEach(func() {
+				desired.Locked = true
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{
+							"resources": [
+								{
+									"metadata": {"guid": "some-bp-guid"},
+									"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 2, "enabled": true}
+								}
+							]
+						}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"name":     "some-bp-name",
+							"stack":    "cflinuxfs2",
+							"position": 2,
+							"enabled":  true,
+							"locked":   true,
+						}),
+						RespondWith(http.StatusCreated, `{
+							"metadata": {"guid": "some-bp-guid"},
+							"entity": {"name": "some-bp-name", "stack": "cflinuxfs2", "position": 2, "enabled": true, "locked": true}
+						}`),
+					),
+				)
+			})
+
+			It("locks the buildpack", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(created).To(BeFalse())
+				Expect(buildpack.Locked).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("ValidateBuildpackForFoundation", func() {
+		var (
+			buildpack  Buildpack
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			warnings, executeErr = client.ValidateBuildpackForFoundation(buildpack)
+		})
+
+		Context("when the buildpack's stack exists", func() {
+			BeforeEach(func() {
+				buildpack = Buildpack{Name: "some-bp-name", Stack: "cflinuxfs3"}
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/stacks"),
+						RespondWith(http.StatusOK, `{
+							"resources": [
+								{"metadata": {"guid": "stack-guid-1"}, "entity": {"name": "cflinuxfs3"}},
+								{"metadata": {"guid": "stack-guid-2"}, "entity": {"name": "windows2016"}}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"stacks warning"}}),
+					),
+				)
+			})
+
+			It("returns no error and the warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("stacks warning"))
+			})
+		})
+
+		Context("when the buildpack's stack does not exist", func() {
+			BeforeEach(func() {
+				buildpack = Buildpack{Name: "some bp", Stack: "windows2016"}
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/stacks"),
+						RespondWith(http.StatusOK, `{
+							"resources": [
+								{"metadata": {"guid": "stack-guid-2"}, "entity": {"name": "cflinuxfs3"}},
+								{"metadata": {"guid": "stack-guid-1"}, "entity": {"name": "cflinuxfs2"}}
+							]
+						}`, http.Header{"X-Cf-Warnings": {"stacks warning"}}),
+					),
+				)
+			})
+
+			It("returns every problem, naming the available stacks", func() {
+				Expect(executeErr).To(MatchError(ccerror.BuildpackValidationError{Problems: []string{
+					"name 'some bp' must only contain alphanumeric characters, underscores, and dashes",
+					"stack 'windows2016' does not exist; available stacks are 'cflinuxfs2', 'cflinuxfs3'",
+				}}))
+				Expect(warnings).To(ConsistOf("stacks warning"))
+			})
+		})
+
+		Context("when the buildpack has no stack", func() {
+			BeforeEach(func() {
+				buildpack = Buildpack{Name: "some-bp-name"}
+			})
+
+			It("does not look up the stacks", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+
+		Context("when getting the stacks fails", func() {
+			BeforeEach(func() {
+				buildpack = Buildpack{Name: "some-bp-name", Stack: "cflinuxfs3"}
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/stacks"),
+						RespondWith(http.StatusTeapot, `{}`, http.Header{"X-Cf-Warnings": {"stacks warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and the warnings", func() {
+				Expect(executeErr).To(HaveOccurred())
+				Expect(warnings).To(ConsistOf("stacks warning"))
+			})
+		})
 	})
 })
+
+// failingReaderAt is a buildpack reader whose reads always fail.
+type failingReaderAt struct {
+	err error
+}
+
+func (reader failingReaderAt) Read([]byte) (int, error) {
+	return 0, reader.err
+}
+
+func (reader failingReaderAt) ReadAt([]byte, int64) (int, error) {
+	return 0, reader.err
+}
@@ -0,0 +1,62 @@
+package ccv2
+
+import (
+	"io"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+)
+
+// BuildpackUploadSpec describes the bits to upload for one buildpack in a
+// batch. The fields match the arguments of UploadBuildpack.
+type BuildpackUploadSpec struct {
+	BuildpackGUID   string
+	BuildpackPath   string
+	Buildpack       io.Reader
+	BuildpackLength int64
+}
+
+// UploadBuildpacksOptions configures UploadBuildpacksWithOptions.
+type UploadBuildpacksOptions struct {
+	// ContinueOnError uploads the remaining buildpacks after an upload fails.
+	// All failures are then returned in a ccerror.BuildpackUploadsError.
+	ContinueOnError bool
+}
+
+// UploadBuildpacks uploads the bits of several buildpacks one after another
+// and stops at the first upload that fails. The warnings of each attempted
+// upload are returned in the order of specs.
+//
+// The uploads are made sequentially with the client's connection, so idle
+// connections are kept alive and reused between them instead of a new
+// connection being opened for each buildpack.
+func (client *Client) UploadBuildpacks(specs []BuildpackUploadSpec) ([]Warnings, error) {
+	return client.UploadBuildpacksWithOptions(specs, UploadBuildpacksOptions{})
+}
+
+// UploadBuildpacksWithOptions behaves like UploadBuildpacks, adjusted by the
+// provided options.
+func (client *Client) UploadBuildpacksWithOptions(specs []BuildpackUploadSpec, options UploadBuildpacksOptions) ([]Warnings, error) {
+	allWarnings := make([]Warnings, 0, len(specs))
+	var failures []ccerror.BuildpackUploadFailure
+
+	for _, spec := range specs {
+		warnings, err := client.UploadBuildpack(spec.BuildpackGUID, spec.BuildpackPath, spec.Buildpack, spec.BuildpackLength)
+		allWarnings = append(allWarnings, warnings)
+		if err == nil {
+			continue
+		}
+
+		if !options.ContinueOnError {
+			return allWarnings, err
+		}
+		failures = append(failures, ccerror.BuildpackUploadFailure{
+			BuildpackGUID: spec.BuildpackGUID,
+			Err:           err,
+		})
+	}
+
+	if len(failures) > 0 {
+		return allWarnings, ccerror.BuildpackUploadsError{Failures: failures}
+	}
+	return allWarnings, nil
+}
@@ -0,0 +1,109 @@
+package ccv2_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("BuildpackUploadSpec", func() {
+	var (
+		client      *Client
+		specs       []BuildpackUploadSpec
+		remoteAddrs []string
+	)
+
+	uploadHandler := func(guid string, status int, body string, warning string) http.HandlerFunc {
+		return CombineHandlers(
+			VerifyRequest(http.MethodPut, "/v2/buildpacks/"+guid+"/bits"),
+			func(_ http.ResponseWriter, req *http.Request) {
+				_, err := ioutil.ReadAll(req.Body)
+				Expect(err).ToNot(HaveOccurred())
+				remoteAddrs = append(remoteAddrs, req.RemoteAddr)
+			},
+			RespondWith(status, body, http.Header{"X-Cf-Warnings": {warning}}),
+		)
+	}
+
+	BeforeEach(func() {
+		client = NewTestClient()
+		remoteAddrs = nil
+
+		specs = nil
+		for _, guid := range []string{"bp-1-guid", "bp-2-guid", "bp-3-guid"} {
+			content := "some-content"
+			specs = append(specs, BuildpackUploadSpec{
+				BuildpackGUID:   guid,
+				BuildpackPath:   guid + ".zip",
+				Buildpack:       strings.NewReader(content),
+				BuildpackLength: int64(len(content)),
+			})
+		}
+	})
+
+	Describe("UploadBuildpacks", func() {
+		Context("when every upload succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					uploadHandler("bp-1-guid", http.StatusCreated, "{}", "warning 1"),
+					uploadHandler("bp-2-guid", http.StatusCreated, "{}", "warning 2"),
+					uploadHandler("bp-3-guid", http.StatusCreated, "{}", "warning 3"),
+				)
+			})
+
+			It("uploads them in order over one connection and returns the warnings of each", func() {
+				warnings, err := client.UploadBuildpacks(specs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(Equal([]Warnings{{"warning 1"}, {"warning 2"}, {"warning 3"}}))
+
+				Expect(remoteAddrs).To(HaveLen(3))
+				Expect(remoteAddrs[1]).To(Equal(remoteAddrs[0]))
+				Expect(remoteAddrs[2]).To(Equal(remoteAddrs[0]))
+			})
+		})
+
+		Context("when an upload fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					uploadHandler("bp-1-guid", http.StatusCreated, "{}", "warning 1"),
+					uploadHandler("bp-2-guid", http.StatusNotFound, `{"code": 10000, "description": "Buildpack not found", "error_code": "CF-NotFound"}`, "warning 2"),
+				)
+			})
+
+			It("stops and returns the error with the warnings of the attempted uploads", func() {
+				warnings, err := client.UploadBuildpacks(specs)
+				Expect(err).To(MatchError(ccerror.ResourceNotFoundError{Message: "Buildpack not found"}))
+				Expect(warnings).To(Equal([]Warnings{{"warning 1"}, {"warning 2"}}))
+			})
+		})
+	})
+
+	Describe("UploadBuildpacksWithOptions", func() {
+		Context("when ContinueOnError is set and uploads fail", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					uploadHandler("bp-1-guid", http.StatusNotFound, `{"code": 10000, "description": "Buildpack not found", "error_code": "CF-NotFound"}`, "warning 1"),
+					uploadHandler("bp-2-guid", http.StatusCreated, "{}", "warning 2"),
+					uploadHandler("bp-3-guid", http.StatusNotFound, `{"code": 10000, "description": "Buildpack not found", "error_code": "CF-NotFound"}`, "warning 3"),
+				)
+			})
+
+			It("uploads every buildpack and returns all failures", func() {
+				warnings, err := client.UploadBuildpacksWithOptions(specs, UploadBuildpacksOptions{ContinueOnError: true})
+				Expect(err).To(MatchError(ccerror.BuildpackUploadsError{
+					Failures: []ccerror.BuildpackUploadFailure{
+						{BuildpackGUID: "bp-1-guid", Err: ccerror.ResourceNotFoundError{Message: "Buildpack not found"}},
+						{BuildpackGUID: "bp-3-guid", Err: ccerror.ResourceNotFoundError{Message: "Buildpack not found"}},
+					},
+				}))
+				Expect(warnings).To(Equal([]Warnings{{"warning 1"}, {"warning 2"}, {"warning 3"}}))
+			})
+		})
+	})
+})
@@ -1,14 +1,29 @@
 package ccv2_test
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"runtime"
+	"strings"
+	"sync"
+	"testing/iotest"
+	"time"
 
 	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/ccv2fakes"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/ghttp"
 )
@@ -56,4 +71,744 @@ var _ = Describe("Cloud Controller Client", func() {
 			Expect(server.ReceivedRequests()).To(HaveLen(2))
 		})
 	})
+
+	Describe("User Agent Suffix", func() {
+		BeforeEach(func() {
+			expectedUserAgent := fmt.Sprintf("CF CLI API V2 Test/Unknown (%s; %s %s) migration-tool/upload", runtime.Version(), runtime.GOARCH, runtime.GOOS)
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+					VerifyHeaderKV("User-Agent", expectedUserAgent),
+					RespondWith(http.StatusOK, `{"resources": []}`),
+				),
+			)
+		})
+
+		It("appends the suffix for the client returned by WithUserAgentSuffix", func() {
+			_, _, err := client.WithUserAgentSuffix("migration-tool/upload").GetBuildpacks()
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("WithUploadFilename", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+					func(_ http.ResponseWriter, req *http.Request) {
+						body, err := ioutil.ReadAll(req.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(req.ContentLength).To(BeEquivalentTo(len(body)))
+
+						_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+						Expect(err).ToNot(HaveOccurred())
+						part, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).NextPart()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(part.FileName()).To(Equal("ruby-buildpack-v1.2.zip"))
+					},
+					RespondWith(http.StatusOK, "{}"),
+				),
+			)
+		})
+
+		It("records the provided file name instead of the base name of the path", func() {
+			_, err := client.WithUploadFilename("ruby-buildpack-v1.2.zip").UploadBuildpack("some-bp-guid", "/tmp/upload-123456.tmp", strings.NewReader("some-content"), 12)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("Upload Expect Continue Timeout", func() {
+		var bytesSent int64
+
+		BeforeEach(func() {
+			bytesSent = 0
+			client = NewTestClient(Config{UploadExpectContinueTimeout: 5 * time.Second}).WithUploadProgress(func(sent int64, _ int64) {
+				bytesSent = sent
+			})
+		})
+
+		Context("when the Cloud Controller rejects the upload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+						VerifyHeaderKV("Expect", "100-continue"),
+						RespondWith(http.StatusUnauthorized, `{"code": 1000, "description": "Invalid Auth Token", "error_code": "CF-InvalidAuthToken"}`),
+					),
+				)
+			})
+
+			It("does not send the bits", func() {
+				_, err := client.UploadBuildpack("some-bp-guid", "some-bp.zip", strings.NewReader("some-content"), 12)
+				Expect(err).To(HaveOccurred())
+				Expect(bytesSent).To(BeZero())
+			})
+		})
+
+		Context("when the Cloud Controller accepts the upload", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							body, err := ioutil.ReadAll(req.Body)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(string(body)).To(ContainSubstring("some-content"))
+						},
+						RespondWith(http.StatusCreated, "{}"),
+					),
+				)
+			})
+
+			It("sends the bits once the Cloud Controller continues", func() {
+				_, err := client.UploadBuildpack("some-bp-guid", "some-bp.zip", strings.NewReader("some-content"), 12)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(bytesSent).To(BeNumerically(">", 12))
+			})
+		})
+	})
+
+	Describe("Upload Rate Limiter", func() {
+		It("shares the throughput budget between concurrent uploads", func() {
+			limiter := NewUploadRateLimiter(10000)
+			client1 := NewTestClient(Config{UploadRateLimiter: limiter})
+			client2 := NewTestClient(Config{UploadRateLimiter: limiter})
+
+			for i := 0; i < 2; i++ {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+						RespondWith(http.StatusOK, "{}"),
+					),
+				)
+			}
+
+			content := strings.Repeat("a", 6000)
+			upload := func(client *Client, done chan<- error) {
+				_, err := client.UploadBuildpack("some-bp-guid", "some-bp.zip", strings.NewReader(content), int64(len(content)))
+				done <- err
+			}
+
+			start := time.Now()
+			done := make(chan error, 2)
+			go upload(client1, done)
+			go upload(client2, done)
+			Expect(<-done).ToNot(HaveOccurred())
+			Expect(<-done).ToNot(HaveOccurred())
+
+			// 12000 bytes at 10000 bytes per second, after a burst of 10000.
+			Expect(time.Since(start)).To(BeNumerically(">=", 150*time.Millisecond))
+		})
+
+		It("keeps fractional credit when concurrent uploads refill it often", func() {
+			limiter := NewUploadRateLimiter(2000)
+			client := NewTestClient(Config{UploadRateLimiter: limiter})
+
+			for i := 0; i < 4; i++ {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+						RespondWith(http.StatusOK, "{}"),
+					),
+				)
+			}
+
+			// Reading one byte at a time refills the bucket every half
+			// millisecond, much less than a whole byte's worth of credit each
+			// time concurrent uploads wake up.
+			content := strings.Repeat("a", 600)
+			start := time.Now()
+			done := make(chan error, 4)
+			for i := 0; i < 4; i++ {
+				go func() {
+					_, err := client.UploadBuildpack("some-bp-guid", "some-bp.zip", iotest.OneByteReader(strings.NewReader(content)), int64(len(content)))
+					done <- err
+				}()
+			}
+			for i := 0; i < 4; i++ {
+				Expect(<-done).ToNot(HaveOccurred())
+			}
+
+			// 2400 bytes at 2000 bytes per second, after a burst of 2000.
+			Expect(time.Since(start)).To(BeNumerically(">=", 150*time.Millisecond))
+			Expect(time.Since(start)).To(BeNumerically("<", 2*time.Second))
+		})
+
+		DescribeTable("does not limit uploads when the rate is not positive",
+			func(bytesPerSecond int64) {
+				client := NewTestClient(Config{UploadRateLimiter: NewUploadRateLimiter(bytesPerSecond)})
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+						RespondWith(http.StatusOK, "{}"),
+					),
+				)
+
+				done := make(chan error, 1)
+				go func() {
+					_, err := client.UploadBuildpack("some-bp-guid", "some-bp.zip", strings.NewReader("some-content"), 12)
+					done <- err
+				}()
+				Eventually(done).Should(Receive(BeNil()))
+			},
+			Entry("zero", int64(0)),
+			Entry("negative", int64(-1)),
+		)
+	})
+
+	Describe("Accept Language", func() {
+		Context("when an accept language is configured", func() {
+			BeforeEach(func() {
+				client = NewTestClient(Config{AcceptLanguage: "de-DE"})
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						VerifyHeaderKV("Accept-Language", "de-DE"),
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("sends the Accept-Language header", func() {
+				_, _, err := client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when no accept language is configured", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							Expect(req.Header).ToNot(HaveKey("Accept-Language"))
+						},
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("does not send an Accept-Language header", func() {
+				_, _, err := client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Buildpack Priority", func() {
+		Context("when a priority is configured", func() {
+			BeforeEach(func() {
+				client = NewTestClient(Config{BuildpackPriority: "low"})
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						VerifyHeaderKV("X-Request-Priority", "low"),
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/stacks"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							Expect(req.Header).ToNot(HaveKey("X-Request-Priority"))
+						},
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("sends it with buildpack requests only", func() {
+				_, _, err := client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+				_, _, err = client.GetStacks()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when a priority header is configured", func() {
+			BeforeEach(func() {
+				client = NewTestClient(Config{BuildpackPriority: "bulk", BuildpackPriorityHeader: "X-Qos-Class"})
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+						VerifyHeaderKV("X-Qos-Class", "bulk"),
+						RespondWith(http.StatusCreated, "{}"),
+					),
+				)
+			})
+
+			It("sends the priority in that header", func() {
+				_, err := client.UploadBuildpack("some-bp-guid", "some-bp.zip", strings.NewReader("some-content"), 12)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when no priority is configured", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							Expect(req.Header).ToNot(HaveKey("X-Request-Priority"))
+						},
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("does not send a priority header", func() {
+				_, _, err := client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Buildpack Trailing Slash", func() {
+		Context("when trailing slashes are appended", func() {
+			BeforeEach(func() {
+				client = NewTestClient(Config{BuildpackTrailingSlash: constant.TrailingSlashAppend})
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks/some-bp-guid/"),
+						RespondWith(http.StatusOK, `{"metadata": {"guid": "some-bp-guid"}, "entity": {"name": "some-bp"}}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/stacks/some-stack-guid"),
+						RespondWith(http.StatusOK, `{"metadata": {"guid": "some-stack-guid"}, "entity": {"name": "some-stack"}}`),
+					),
+				)
+			})
+
+			It("appends them to buildpack requests only", func() {
+				_, _, err := client.GetBuildpack("some-bp-guid")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = client.GetStack("some-stack-guid")
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when trailing slashes are stripped", func() {
+			BeforeEach(func() {
+				client = NewTestClient(Config{BuildpackTrailingSlash: constant.TrailingSlashStrip})
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{"next_url": "/v2/buildpacks/?page=2", "resources": []}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks", "page=2"),
+						RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`),
+					),
+				)
+			})
+
+			It("strips them from the pages of buildpack listings", func() {
+				_, _, err := client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Buildpack List Cache", func() {
+		BeforeEach(func() {
+			client = NewTestClient(Config{CacheBuildpackLists: true})
+
+			listResponse := `{
+				"next_url": null,
+				"resources": [
+					{"metadata": {"guid": "some-bp-guid"}, "entity": {"name": "some-bp", "position": 1}}
+				]
+			}`
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+					RespondWith(http.StatusOK, listResponse, http.Header{"Etag": {`"v1"`}}),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			_, _, err := client.GetBuildpacks()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the listing is not modified", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						VerifyHeaderKV("If-None-Match", `"v1"`),
+						RespondWith(http.StatusNotModified, nil, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("reuses the cached page", func() {
+				buildpacks, warnings, err := client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(buildpacks).To(ConsistOf(Buildpack{GUID: "some-bp-guid", Name: "some-bp", Position: 1}))
+			})
+		})
+
+		Context("when a buildpack is changed", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodDelete, "/v2/buildpacks/some-bp-guid"),
+						RespondWith(http.StatusNoContent, nil),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							Expect(req.Header).ToNot(HaveKey("If-None-Match"))
+						},
+						RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`),
+					),
+				)
+			})
+
+			It("clears the cache", func() {
+				_, err := client.DeleteBuildpack("some-bp-guid")
+				Expect(err).ToNot(HaveOccurred())
+
+				buildpacks, _, err := client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buildpacks).To(BeEmpty())
+			})
+		})
+
+		Context("when the listing is made with different headers", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							Expect(req.Header).ToNot(HaveKey("If-None-Match"))
+						},
+						RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`),
+					),
+				)
+			})
+
+			It("does not use the page cached for other headers", func() {
+				buildpacks, _, err := client.WithHeaders(http.Header{"X-Some-Header": {"some-value"}}).GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buildpacks).To(BeEmpty())
+			})
+
+			It("does not use the page cached for another language", func() {
+				buildpacks, _, err := client.WithHeaders(http.Header{"Accept-Language": {"fr"}}).GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buildpacks).To(BeEmpty())
+			})
+		})
+
+		Context("when a listing is made while a buildpack is being changed", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodDelete, "/v2/buildpacks/some-bp-guid"),
+						func(http.ResponseWriter, *http.Request) {
+							_, _, err := client.GetBuildpacks()
+							Expect(err).ToNot(HaveOccurred())
+						},
+						RespondWith(http.StatusNoContent, nil),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						RespondWith(http.StatusOK, `{
+							"next_url": null,
+							"resources": [
+								{"metadata": {"guid": "some-bp-guid"}, "entity": {"name": "some-bp", "position": 1}}
+							]
+						}`, http.Header{"Etag": {`"v2"`}}),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							Expect(req.Header).ToNot(HaveKey("If-None-Match"))
+						},
+						RespondWith(http.StatusOK, `{"next_url": null, "resources": []}`),
+					),
+				)
+			})
+
+			It("clears the cache once the change completes", func() {
+				_, err := client.DeleteBuildpack("some-bp-guid")
+				Expect(err).ToNot(HaveOccurred())
+
+				buildpacks, _, err := client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buildpacks).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Filter Transformer", func() {
+		BeforeEach(func() {
+			client = NewTestClient(Config{
+				FilterTransformer: func(filters []Filter) []Filter {
+					return append([]Filter{{
+						Type:     constant.StackFilter,
+						Operator: constant.EqualOperator,
+						Values:   []string{"cflinuxfs2"},
+					}}, filters...)
+				},
+			})
+
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks", "q=stack:cflinuxfs2&q=name:some-bp"),
+					RespondWith(http.StatusOK, `{"resources": []}`),
+				),
+			)
+		})
+
+		It("applies the transformed filters to buildpack listings", func() {
+			_, _, err := client.GetBuildpacks(Filter{
+				Type:     constant.NameFilter,
+				Operator: constant.EqualOperator,
+				Values:   []string{"some-bp"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("Proxy URL", func() {
+		var (
+			proxy       *httptest.Server
+			proxyMutex  sync.Mutex
+			proxyAuths  []string
+			tunnelAddrs []string
+		)
+
+		BeforeEach(func() {
+			proxyAuths = nil
+			tunnelAddrs = nil
+
+			proxy = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				Expect(req.Method).To(Equal(http.MethodConnect))
+
+				upstream, err := net.Dial("tcp", req.Host)
+				Expect(err).ToNot(HaveOccurred())
+
+				proxyMutex.Lock()
+				proxyAuths = append(proxyAuths, req.Header.Get("Proxy-Authorization"))
+				tunnelAddrs = append(tunnelAddrs, upstream.LocalAddr().String())
+				proxyMutex.Unlock()
+
+				downstream, _, err := w.(http.Hijacker).Hijack()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = downstream.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+				Expect(err).ToNot(HaveOccurred())
+
+				go func() {
+					_, _ = io.Copy(upstream, downstream)
+					upstream.Close()
+				}()
+				go func() {
+					_, _ = io.Copy(downstream, upstream)
+					downstream.Close()
+				}()
+			}))
+
+			proxyURL, err := url.Parse(proxy.URL)
+			Expect(err).ToNot(HaveOccurred())
+			proxyURL.User = url.UserPassword("some-user", "some-password")
+
+			client = NewTestClient(Config{ProxyURL: proxyURL})
+
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+					func(_ http.ResponseWriter, req *http.Request) {
+						_, err := ioutil.ReadAll(req.Body)
+						Expect(err).ToNot(HaveOccurred())
+
+						proxyMutex.Lock()
+						defer proxyMutex.Unlock()
+						Expect(tunnelAddrs).To(ContainElement(req.RemoteAddr))
+					},
+					RespondWith(http.StatusCreated, "{}"),
+				),
+			)
+		})
+
+		AfterEach(func() {
+			proxy.Close()
+		})
+
+		It("uploads buildpack bits through the proxy with its credentials", func() {
+			_, err := client.UploadBuildpack("some-bp-guid", "some-bp.zip", strings.NewReader("some-content"), 12)
+			Expect(err).ToNot(HaveOccurred())
+
+			proxyMutex.Lock()
+			defer proxyMutex.Unlock()
+			Expect(proxyAuths).ToNot(BeEmpty())
+			for _, auth := range proxyAuths {
+				Expect(auth).To(Equal("Basic " + base64.StdEncoding.EncodeToString([]byte("some-user:some-password"))))
+			}
+		})
+	})
+
+	Describe("WithAPIRoot", func() {
+		var otherServer *Server
+
+		BeforeEach(func() {
+			otherServer = NewTLSServer()
+
+			otherServer.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+					RespondWith(http.StatusOK, `{
+						"next_url": "/v2/buildpacks?page=2",
+						"resources": [{"metadata": {"guid": "dr-guid-1"}, "entity": {"name": "bp-1"}}]
+					}`),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks", "page=2"),
+					RespondWith(http.StatusOK, `{
+						"resources": [{"metadata": {"guid": "dr-guid-2"}, "entity": {"name": "bp-2"}}]
+					}`),
+				),
+			)
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+					RespondWith(http.StatusOK, `{
+						"resources": [{"metadata": {"guid": "primary-guid"}, "entity": {"name": "bp-1"}}]
+					}`),
+				),
+			)
+		})
+
+		AfterEach(func() {
+			otherServer.Close()
+		})
+
+		It("sends the returned client's requests to the other API root only", func() {
+			drClient := client.WithAPIRoot(otherServer.URL())
+			Expect(drClient.API()).To(Equal(otherServer.URL()))
+
+			buildpacks, _, err := drClient.GetBuildpacks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildpacks).To(HaveLen(2))
+			Expect(buildpacks[1].GUID).To(Equal("dr-guid-2"))
+
+			buildpacks, _, err = client.GetBuildpacks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildpacks).To(HaveLen(1))
+			Expect(buildpacks[0].GUID).To(Equal("primary-guid"))
+		})
+	})
+
+	Describe("Request URL Rewriter", func() {
+		BeforeEach(func() {
+			client = NewTestClient(Config{
+				RequestURLRewriter: func(requestURL *url.URL) {
+					requestURL.Path = strings.Replace(requestURL.Path, "/v2/buildpacks", "/mock/v2/buildpacks", 1)
+				},
+			})
+
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodGet, "/mock/v2/buildpacks"),
+					RespondWith(http.StatusOK, `{"resources": []}`),
+				),
+			)
+		})
+
+		It("sends requests to the rewritten URL", func() {
+			_, _, err := client.GetBuildpacks()
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("Extra Headers", func() {
+		BeforeEach(func() {
+			client = NewTestClient(Config{
+				ExtraHeaders: http.Header{
+					"X-Foundation": {"prod"},
+					"Content-Type": {"text/plain"},
+				},
+			})
+		})
+
+		Context("when making a request", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						VerifyHeaderKV("X-Foundation", "prod"),
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("adds the extra headers", func() {
+				_, _, err := client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when uploading a buildpack", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+						VerifyHeaderKV("X-Foundation", "prod"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							Expect(req.Header["Content-Type"]).To(HaveLen(1))
+							Expect(req.Header.Get("Content-Type")).To(HavePrefix("multipart/form-data"))
+						},
+						RespondWith(http.StatusOK, "{}"),
+					),
+				)
+			})
+
+			It("does not replace the multipart content type", func() {
+				_, err := client.UploadBuildpack("some-bp-guid", "some-bp.zip", strings.NewReader("some-content"), 12)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Describe("WithHeaders", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						VerifyHeaderKV("X-Foundation", "staging"),
+						VerifyHeaderKV("X-Request-Source", "some-tool"),
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/buildpacks"),
+						VerifyHeaderKV("X-Foundation", "prod"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							Expect(req.Header.Get("X-Request-Source")).To(BeEmpty())
+						},
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+			})
+
+			It("overrides headers for the returned client only", func() {
+				_, _, err := client.WithHeaders(http.Header{
+					"x-foundation":     {"staging"},
+					"X-Request-Source": {"some-tool"},
+				}).GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = client.GetBuildpacks()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
 })
@@ -4,8 +4,12 @@ package constant
 type FilterType string
 
 const (
+	// ActeeFilter is the name of the 'actee' filter.
+	ActeeFilter FilterType = "actee"
 	// AppGUIDFilter is the name of the 'app_guid' filter.
 	AppGUIDFilter FilterType = "app_guid"
+	// CreatedAtFilter is the name of the 'created_at' filter.
+	CreatedAtFilter FilterType = "created_at"
 	// DomainGUIDFilter is the name of the 'domain_guid' filter.
 	DomainGUIDFilter FilterType = "domain_guid"
 	// OrganizationGUIDFilter is the name of the 'organization_guid' filter.
@@ -22,6 +26,8 @@ const (
 	ServicePlanGUIDFilter FilterType = "service_plan_guid"
 	// SpaceGUIDFilter is the name of the 'space_guid' filter.
 	SpaceGUIDFilter FilterType = "space_guid"
+	// StackFilter is the name of the 'stack' filter.
+	StackFilter FilterType = "stack"
 
 	// LabelFilter is the name of the 'label' filter.
 	LabelFilter FilterType = "label"
@@ -52,4 +58,7 @@ const (
 
 	// InOperator is the Filter's "IN" operator.
 	InOperator FilterOperator = " IN "
+
+	// LessThanOperator is the query less than operator.
+	LessThanOperator FilterOperator = "<"
 )
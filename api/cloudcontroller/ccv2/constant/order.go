@@ -0,0 +1,23 @@
+package constant
+
+// OrderBy is the field a list of resources is ordered by.
+type OrderBy string
+
+const (
+	// OrderByCreatedAt orders resources by creation time.
+	OrderByCreatedAt OrderBy = "created_at"
+	// OrderByName orders resources by name.
+	OrderByName OrderBy = "name"
+	// OrderByPosition orders resources by position.
+	OrderByPosition OrderBy = "position"
+)
+
+// OrderDirection is the direction a list of resources is ordered in.
+type OrderDirection string
+
+const (
+	// AscendingOrder lists resources from lowest to highest.
+	AscendingOrder OrderDirection = "asc"
+	// DescendingOrder lists resources from highest to lowest.
+	DescendingOrder OrderDirection = "desc"
+)
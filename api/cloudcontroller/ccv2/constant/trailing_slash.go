@@ -0,0 +1,13 @@
+package constant
+
+// TrailingSlash is how the trailing slash of a request path is handled.
+type TrailingSlash string
+
+const (
+	// TrailingSlashUnchanged sends the path as it is built.
+	TrailingSlashUnchanged TrailingSlash = ""
+	// TrailingSlashAppend ends the path with a slash.
+	TrailingSlashAppend TrailingSlash = "append"
+	// TrailingSlashStrip removes any trailing slash from the path.
+	TrailingSlashStrip TrailingSlash = "strip"
+)
@@ -114,3 +114,9 @@ func handleUnprocessableEntity(errorResponse ccerror.V2ErrorResponse) error {
 	}
 	return ccerror.UnprocessableEntityError{Message: errorResponse.Description}
 }
+
+// IsRetryable returns true if the request that returned err may succeed when
+// made again. It classifies errors the same way as ccerror.IsRetryable.
+func IsRetryable(err error) bool {
+	return ccerror.IsRetryable(err)
+}
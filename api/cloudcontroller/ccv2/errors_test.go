@@ -1,12 +1,15 @@
 package ccv2_test
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/ghttp"
 )
@@ -309,4 +312,31 @@ var _ = Describe("Error Wrapper", func() {
 			})
 		})
 	})
+
+	Describe("IsRetryable", func() {
+		DescribeTable("classifies errors",
+			func(err error, retryable bool) {
+				Expect(IsRetryable(err)).To(Equal(retryable))
+			},
+			Entry("a 500", ccerror.V2UnexpectedResponseError{ResponseCode: http.StatusInternalServerError}, true),
+			Entry("a 502 without a Cloud Controller body", ccerror.V2UnexpectedResponseError{ResponseCode: http.StatusBadGateway}, true),
+			Entry("a 429", ccerror.V2UnexpectedResponseError{ResponseCode: http.StatusTooManyRequests}, true),
+			Entry("a 429 without a Cloud Controller body", ccerror.UnknownHTTPSourceError{StatusCode: http.StatusTooManyRequests}, true),
+			Entry("a 409", ccerror.V2UnexpectedResponseError{ResponseCode: http.StatusConflict}, false),
+			Entry("a 404 without a Cloud Controller body", ccerror.UnknownHTTPSourceError{StatusCode: http.StatusNotFound}, false),
+			Entry("a 404", ccerror.ResourceNotFoundError{}, false),
+			Entry("a 401", ccerror.UnauthorizedError{}, false),
+			Entry("an upload timeout", ccerror.UploadTimeoutError{}, true),
+			Entry("a network timeout", ccerror.RequestError{Err: &url.Error{Op: "Get", URL: "https://api.example.com", Err: timeoutError{}}}, true),
+			Entry("a refused connection", ccerror.RequestError{Err: &url.Error{Op: "Get", URL: "https://api.example.com", Err: errors.New("connection refused")}}, false),
+			Entry("a local validation error", ccerror.EmptyBuildpackGUIDError{}, false),
+			Entry("no error", nil, false),
+		)
+	})
 })
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -80,6 +80,16 @@ func (event *Event) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// GetBuildpackEvents returns back the Events whose actee is the buildpack with
+// the provided GUID.
+func (client *Client) GetBuildpackEvents(guid string) ([]Event, Warnings, error) {
+	return client.GetEvents(Filter{
+		Type:     constant.ActeeFilter,
+		Operator: constant.EqualOperator,
+		Values:   []string{guid},
+	})
+}
+
 // GetEvents returns back a list of Events based off of the provided queries.
 func (client *Client) GetEvents(filters ...Filter) ([]Event, Warnings, error) {
 	request, err := client.newHTTPRequest(requestOptions{
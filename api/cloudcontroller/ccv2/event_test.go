@@ -19,6 +19,59 @@ var _ = Describe("Event", func() {
 		client = NewTestClient()
 	})
 
+	Describe("GetBuildpackEvents", func() {
+		var (
+			events     []Event
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			events, warnings, executeErr = client.GetBuildpackEvents("some-bp-guid")
+		})
+
+		Context("when the cloud controller returns events", func() {
+			BeforeEach(func() {
+				response := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {
+								"guid": "some-event-guid"
+							},
+							"entity": {
+								"type": "audit.buildpack.update",
+								"actee": "some-bp-guid",
+								"actee_type": "buildpack",
+								"actee_name": "some-bp-name"
+							}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/events", "q=actee:some-bp-guid"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the events for the buildpack and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(events).To(Equal([]Event{
+					{
+						GUID:      "some-event-guid",
+						Type:      "audit.buildpack.update",
+						ActeeGUID: "some-bp-guid",
+						ActeeType: "buildpack",
+						ActeeName: "some-bp-name",
+					},
+				}))
+			})
+		})
+	})
+
 	Describe("GetEvents", func() {
 		var (
 			events     []Event
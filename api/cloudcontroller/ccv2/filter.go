@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
 )
@@ -21,16 +22,48 @@ type Filter struct {
 	Values []string
 }
 
-func (filter Filter) format() string {
+// CreatedAfterFilter returns a filter for resources created after the given
+// time.
+func CreatedAfterFilter(after time.Time) Filter {
+	return Filter{
+		Type:     constant.CreatedAtFilter,
+		Operator: constant.GreaterThanOperator,
+		Values:   []string{after.UTC().Format(time.RFC3339)},
+	}
+}
+
+// CreatedBeforeFilter returns a filter for resources created before the given
+// time.
+func CreatedBeforeFilter(before time.Time) Filter {
+	return Filter{
+		Type:     constant.CreatedAtFilter,
+		Operator: constant.LessThanOperator,
+		Values:   []string{before.UTC().Format(time.RFC3339)},
+	}
+}
+
+// String returns the filter as it is sent in a "q" query parameter, such as
+// "name:some-name" or "name IN some-name,other-name", before the query is
+// escaped.
+func (filter Filter) String() string {
 	return fmt.Sprintf("%s%s%s", filter.Type, filter.Operator, strings.Join(filter.Values, ","))
 }
 
+// EscapeFilterValue escapes a filter returned by Filter.String, or a value
+// within one, for use in a query string, the same way the filters of
+// ConvertFilterParameters are escaped when a request is sent. The Cloud
+// Controller has no escape for the comma separating the values of an IN
+// filter, so values containing commas cannot be matched with one.
+func EscapeFilterValue(value string) string {
+	return url.QueryEscape(value)
+}
+
 // ConvertFilterParameters converts a Filter object into a collection that
 // cloudcontroller.Request can accept.
 func ConvertFilterParameters(filters []Filter) url.Values {
 	params := url.Values{"q": []string{}}
 	for _, filter := range filters {
-		params["q"] = append(params["q"], filter.format())
+		params["q"] = append(params["q"], filter.String())
 	}
 
 	return params
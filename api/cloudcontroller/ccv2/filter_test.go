@@ -0,0 +1,40 @@
+package ccv2_test
+
+import (
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter", func() {
+	Describe("String", func() {
+		It("joins the type, operator, and values", func() {
+			Expect(Filter{
+				Type:     constant.NameFilter,
+				Operator: constant.EqualOperator,
+				Values:   []string{"some-bp"},
+			}.String()).To(Equal("name:some-bp"))
+
+			Expect(Filter{
+				Type:     constant.NameFilter,
+				Operator: constant.InOperator,
+				Values:   []string{"bp-1", "bp-2"},
+			}.String()).To(Equal("name IN bp-1,bp-2"))
+		})
+	})
+
+	Describe("EscapeFilterValue", func() {
+		It("escapes filters the same way ConvertFilterParameters does", func() {
+			filter := Filter{
+				Type:     constant.NameFilter,
+				Operator: constant.InOperator,
+				Values:   []string{"some bp", "bp+1&2"},
+			}
+
+			Expect(EscapeFilterValue(filter.String())).To(Equal("name+IN+some+bp%2Cbp%2B1%262"))
+			Expect(ConvertFilterParameters([]Filter{filter}).Encode()).To(Equal("q=" + EscapeFilterValue(filter.String())))
+		})
+	})
+})
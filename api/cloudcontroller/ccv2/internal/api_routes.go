@@ -15,6 +15,7 @@ import (
 //
 // The const name should always be the const value + Request.
 const (
+	DeleteBuildpackRequest                               = "DeleteBuildpack"
 	DeleteOrganizationRequest                            = "DeleteOrganization"
 	DeleteRouteAppRequest                                = "DeleteRouteApp"
 	DeleteRouteRequest                                   = "DeleteRoute"
@@ -27,6 +28,8 @@ const (
 	GetAppRoutesRequest                                  = "GetAppRoutes"
 	GetAppsRequest                                       = "GetApps"
 	GetAppStatsRequest                                   = "GetAppStats"
+	GetBuildpackDownloadRequest                          = "GetBuildpackDownload"
+	GetBuildpackRequest                                  = "GetBuildpack"
 	GetBuildpacksRequest                                 = "GetBuildpacks"
 	GetConfigFeatureFlagsRequest                         = "GetConfigFeatureFlags"
 	GetEventsRequest                                     = "GetEvents"
@@ -75,6 +78,7 @@ const (
 	GetUserProvidedServiceInstanceServiceBindingsRequest = "GetUserProvidedServiceInstanceServiceBindings"
 	GetUserProvidedServiceInstancesRequest               = "GetUserProvidedServiceInstances"
 	GetUsersRequest                                      = "GetUsers"
+	HeadBuildpackDownloadRequest                         = "HeadBuildpackDownload"
 	PostAppRequest                                       = "PostApp"
 	PostAppRestageRequest                                = "PostAppRestage"
 	PostBuildpackRequest                                 = "PostBuildpack"
@@ -108,8 +112,12 @@ var APIRoutes = rata.Routes{
 	{Path: "/v2/apps/:app_guid/stats", Method: http.MethodGet, Name: GetAppStatsRequest},
 	{Path: "/v2/buildpacks", Method: http.MethodPost, Name: PostBuildpackRequest},
 	{Path: "/v2/buildpacks", Method: http.MethodGet, Name: GetBuildpacksRequest},
+	{Path: "/v2/buildpacks/:buildpack_guid", Method: http.MethodGet, Name: GetBuildpackRequest},
 	{Path: "/v2/buildpacks/:buildpack_guid", Method: http.MethodPut, Name: PutBuildpackRequest},
+	{Path: "/v2/buildpacks/:buildpack_guid", Method: http.MethodDelete, Name: DeleteBuildpackRequest},
 	{Path: "/v2/buildpacks/:buildpack_guid/bits", Method: http.MethodPut, Name: PutBuildpackBitsRequest},
+	{Path: "/v2/buildpacks/:buildpack_guid/download", Method: http.MethodGet, Name: GetBuildpackDownloadRequest},
+	{Path: "/v2/buildpacks/:buildpack_guid/download", Method: http.MethodHead, Name: HeadBuildpackDownloadRequest},
 	{Path: "/v2/config/feature_flags", Method: http.MethodGet, Name: GetConfigFeatureFlagsRequest},
 	{Path: "/v2/events", Method: http.MethodGet, Name: GetEventsRequest},
 	{Path: "/v2/info", Method: http.MethodGet, Name: GetInfoRequest},
@@ -2,20 +2,59 @@ package ccv2
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"reflect"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 )
 
 // PaginatedResources represents a page of resources returned by the Cloud
 // Controller.
 type PaginatedResources struct {
 	NextURL        string          `json:"next_url"`
+	PrevURL        string          `json:"prev_url"`
 	ResourcesBytes json.RawMessage `json:"resources"`
 	resourceType   reflect.Type
 }
 
+// PaginationLinks are the links to the neighbouring pages returned with a page
+// of resources. A link is empty when there is no such page.
+type PaginationLinks struct {
+	NextURL string
+	PrevURL string
+}
+
+// paginateOptions adjusts how paginateWithOptions requests pages.
+type paginateOptions struct {
+	// maxPages is the maximum number of pages requested. If more pages remain,
+	// a ccerror.PaginationLimitError is returned. Zero or less means no limit.
+	maxPages int
+
+	// onPageLinks, if set, is called with the links of each page received.
+	onPageLinks func(PaginationLinks)
+
+	// discardWarnings skips collecting warnings; nil is returned instead.
+	discardWarnings bool
+
+	// maxResponseSize limits the size of each page's body. Zero or less means
+	// no limit.
+	maxResponseSize int64
+
+	// stop, if set, halts pagination with a ccerror.CancelledError once it is
+	// closed. It is checked before each page is requested.
+	stop <-chan struct{}
+
+	// cache, if set, is used to revalidate pages with their ETags instead of
+	// downloading them again.
+	cache *buildpackListCache
+
+	// onWarning, if set, is called with each warning as its page is received,
+	// whether or not warnings are discarded.
+	onWarning func(string)
+}
+
 // NewPaginatedResources returns a new PaginatedResources struct with the
 // given resource type.
 func NewPaginatedResources(exampleResource interface{}) *PaginatedResources {
@@ -38,21 +77,42 @@ func (pr PaginatedResources) Resources() ([]interface{}, error) {
 	return contents, err
 }
 
+// errStopPagination is returned by an appendToExternalList function that has
+// every item it needs, to stop paginating without requesting further pages.
+var errStopPagination = errors.New("stop pagination")
+
 func (client Client) paginate(request *cloudcontroller.Request, obj interface{}, appendToExternalList func(interface{}) error) (Warnings, error) {
-	fullWarningsList := Warnings{}
+	return client.paginateWithOptions(request, obj, paginateOptions{}, appendToExternalList)
+}
 
-	for {
-		wrapper := NewPaginatedResources(obj)
-		response := cloudcontroller.Response{
-			Result: &wrapper,
+// paginateWithOptions behaves like paginate, adjusted by the provided options.
+func (client Client) paginateWithOptions(request *cloudcontroller.Request, obj interface{}, options paginateOptions, appendToExternalList func(interface{}) error) (Warnings, error) {
+	var fullWarningsList Warnings
+	if !options.discardWarnings {
+		fullWarningsList = Warnings{}
+	}
+
+	for page := 1; ; page++ {
+		select {
+		case <-options.stop:
+			return fullWarningsList, ccerror.CancelledError{}
+		default:
 		}
 
-		err := client.connection.Make(request, &response)
-		fullWarningsList = append(fullWarningsList, response.Warnings...)
+		wrapper := NewPaginatedResources(obj)
+		warnings, err := client.requestPage(request, wrapper, options)
+		fullWarningsList = collectPageWarnings(fullWarningsList, warnings, options)
 		if err != nil {
 			return fullWarningsList, err
 		}
 
+		if options.onPageLinks != nil {
+			options.onPageLinks(PaginationLinks{
+				NextURL: wrapper.NextURL,
+				PrevURL: wrapper.PrevURL,
+			})
+		}
+
 		list, err := wrapper.Resources()
 		if err != nil {
 			return fullWarningsList, err
@@ -60,6 +120,9 @@ func (client Client) paginate(request *cloudcontroller.Request, obj interface{},
 
 		for _, item := range list {
 			err = appendToExternalList(item)
+			if err == errStopPagination {
+				return fullWarningsList, nil
+			}
 			if err != nil {
 				return fullWarningsList, err
 			}
@@ -69,6 +132,13 @@ func (client Client) paginate(request *cloudcontroller.Request, obj interface{},
 			break
 		}
 
+		if options.maxPages > 0 && page >= options.maxPages {
+			return fullWarningsList, ccerror.PaginationLimitError{
+				MaxPages: options.maxPages,
+				NextURL:  wrapper.NextURL,
+			}
+		}
+
 		request, err = client.newHTTPRequest(requestOptions{
 			URI:    wrapper.NextURL,
 			Method: http.MethodGet,
@@ -80,3 +150,55 @@ func (client Client) paginate(request *cloudcontroller.Request, obj interface{},
 
 	return fullWarningsList, nil
 }
+
+// collectPageWarnings passes the warnings of a page to options.onWarning and
+// appends them to fullWarningsList unless warnings are discarded.
+func collectPageWarnings(fullWarningsList Warnings, warnings Warnings, options paginateOptions) Warnings {
+	if options.onWarning != nil {
+		for _, warning := range warnings {
+			options.onWarning(warning)
+		}
+	}
+
+	if options.discardWarnings {
+		return fullWarningsList
+	}
+	return append(fullWarningsList, warnings...)
+}
+
+// requestPage requests a single page into wrapper. When options has a cache,
+// the page is revalidated with the ETag it was last received with, and the
+// cached page is used when the Cloud Controller reports it is not modified.
+func (client Client) requestPage(request *cloudcontroller.Request, wrapper *PaginatedResources, options paginateOptions) (Warnings, error) {
+	if options.cache == nil {
+		response := cloudcontroller.Response{
+			Result:      wrapper,
+			MaxBodySize: options.maxResponseSize,
+		}
+		err := client.connection.Make(request, &response)
+		return response.Warnings, err
+	}
+
+	cacheKey := buildpackListCacheKey(request.Request)
+	cached, found := options.cache.get(cacheKey)
+	if found {
+		request.Header.Set("If-None-Match", cached.etag)
+	}
+
+	response := cloudcontroller.Response{
+		MaxBodySize: options.maxResponseSize,
+	}
+	err := client.connection.Make(request, &response)
+	if err != nil {
+		return response.Warnings, err
+	}
+
+	body := response.RawResponse
+	if found && response.HTTPResponse.StatusCode == http.StatusNotModified {
+		body = cached.body
+	} else if etag := response.HTTPResponse.Header.Get("ETag"); etag != "" {
+		options.cache.put(cacheKey, cachedPage{etag: etag, body: body})
+	}
+
+	return response.Warnings, cloudcontroller.DecodeJSON(body, wrapper)
+}
@@ -0,0 +1,84 @@
+package ccv2
+
+import (
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"os"
+)
+
+// PreparedBuildpackUpload is a complete buildpack upload request body written
+// to a temporary file, so it can be sent and resent without rebuilding it.
+type PreparedBuildpackUpload struct {
+	// BuildpackSize is the size in bytes of the buildpack itself.
+	BuildpackSize int64
+
+	// ContentLength is the size in bytes of the whole request body.
+	ContentLength int64
+
+	// ContentType is the multipart Content-Type of the request body.
+	ContentType string
+
+	body *os.File
+}
+
+// PrepareBuildpackUpload writes the upload request body for the buildpack at
+// buildpackPath to a temporary file. The returned cleanup function closes and
+// removes the file; it must be called once the upload is no longer needed.
+func (client *Client) PrepareBuildpackUpload(buildpackPath string) (PreparedBuildpackUpload, func() error, error) {
+	noCleanup := func() error { return nil }
+
+	buildpack, err := os.Open(buildpackPath)
+	if err != nil {
+		return PreparedBuildpackUpload{}, noCleanup, err
+	}
+	defer buildpack.Close()
+
+	body, err := ioutil.TempFile("", "cf-buildpack-upload-")
+	if err != nil {
+		return PreparedBuildpackUpload{}, noCleanup, err
+	}
+	cleanup := func() error {
+		body.Close()
+		return os.Remove(body.Name())
+	}
+
+	prepared, err := client.writePreparedBuildpackUpload(body, buildpack, buildpackPath)
+	if err != nil {
+		_ = cleanup()
+		return PreparedBuildpackUpload{}, noCleanup, err
+	}
+
+	return prepared, cleanup, nil
+}
+
+func (client *Client) writePreparedBuildpackUpload(body *os.File, buildpack io.Reader, buildpackPath string) (PreparedBuildpackUpload, error) {
+	form := multipart.NewWriter(body)
+
+	writer, err := client.createBuildpackFormFile(form, buildpackPath)
+	if err != nil {
+		return PreparedBuildpackUpload{}, err
+	}
+
+	buildpackSize, err := io.Copy(writer, buildpack)
+	if err != nil {
+		return PreparedBuildpackUpload{}, err
+	}
+
+	err = form.Close()
+	if err != nil {
+		return PreparedBuildpackUpload{}, err
+	}
+
+	contentLength, err := body.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return PreparedBuildpackUpload{}, err
+	}
+
+	return PreparedBuildpackUpload{
+		BuildpackSize: buildpackSize,
+		ContentLength: contentLength,
+		ContentType:   form.FormDataContentType(),
+		body:          body,
+	}, nil
+}
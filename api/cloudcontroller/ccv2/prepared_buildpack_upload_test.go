@@ -0,0 +1,103 @@
+package ccv2_test
+
+import (
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("PreparedBuildpackUpload", func() {
+	var (
+		client        *Client
+		tempDir       string
+		buildpackPath string
+	)
+
+	BeforeEach(func() {
+		client = NewTestClient(Config{BuildpackBitsNotReadyRetryInterval: time.Millisecond})
+
+		var err error
+		tempDir, err = ioutil.TempDir("", "prepared-buildpack-test")
+		Expect(err).ToNot(HaveOccurred())
+
+		buildpackPath = filepath.Join(tempDir, "some-buildpack.zip")
+		err = ioutil.WriteFile(buildpackPath, []byte("some-content"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tempDir)).To(Succeed())
+	})
+
+	Describe("PrepareBuildpackUpload and UploadPreparedBuildpack", func() {
+		var (
+			prepared PreparedBuildpackUpload
+			cleanup  func() error
+		)
+
+		BeforeEach(func() {
+			var err error
+			prepared, cleanup, err = client.PrepareBuildpackUpload(buildpackPath)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(cleanup()).To(Succeed())
+		})
+
+		It("records the sizes of the prepared body", func() {
+			Expect(prepared.BuildpackSize).To(BeEquivalentTo(len("some-content")))
+			Expect(prepared.ContentLength).To(BeNumerically(">", prepared.BuildpackSize))
+			Expect(prepared.ContentType).To(HavePrefix("multipart/form-data; boundary="))
+		})
+
+		It("sends the same body again when the upload is retried", func() {
+			verifyBody := func(_ http.ResponseWriter, req *http.Request) {
+				Expect(req.ContentLength).To(Equal(prepared.ContentLength))
+				Expect(req.Header.Get("Content-Type")).To(Equal(prepared.ContentType))
+
+				defer req.Body.Close()
+				requestReader := multipart.NewReader(req.Body, prepared.ContentType[30:])
+				buildpackPart, err := requestReader.NextPart()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buildpackPart.FileName()).To(Equal("some-buildpack.zip"))
+				contents, err := ioutil.ReadAll(buildpackPart)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-content"))
+			}
+
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+					verifyBody,
+					RespondWith(http.StatusConflict, `{"code": 10001, "error_code": "CF-Conflict"}`, http.Header{"X-Cf-Warnings": {"first warning"}}),
+				),
+				CombineHandlers(
+					VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+					verifyBody,
+					RespondWith(http.StatusOK, `{}`, http.Header{"X-Cf-Warnings": {"second warning"}}),
+				),
+			)
+
+			warnings, err := client.UploadPreparedBuildpack("some-bp-guid", prepared)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf("first warning", "second warning"))
+		})
+	})
+
+	Context("when the buildpack file does not exist", func() {
+		It("returns the error and a usable cleanup function", func() {
+			_, cleanup, err := client.PrepareBuildpackUpload(filepath.Join(tempDir, "missing.zip"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+			Expect(cleanup()).To(Succeed())
+		})
+	})
+})
@@ -5,8 +5,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/internal"
 )
 
 // Params represents URI parameters for a request.
@@ -58,9 +61,37 @@ func (client Client) newHTTPRequest(passedRequest requestOptions) (*cloudcontrol
 		return nil, err
 	}
 
+	if client.buildpackTrailingSlash != constant.TrailingSlashUnchanged && isBuildpackRequest(passedRequest) {
+		request.URL.Path = applyTrailingSlash(request.URL.Path, client.buildpackTrailingSlash)
+		if request.URL.RawPath != "" {
+			request.URL.RawPath = applyTrailingSlash(request.URL.RawPath, client.buildpackTrailingSlash)
+		}
+	}
+
+	if client.requestURLRewriter != nil {
+		client.requestURLRewriter(request.URL)
+		request.Host = request.URL.Host
+	}
+
 	request.Header = http.Header{}
+	for name, values := range client.extraHeaders {
+		for _, value := range values {
+			request.Header.Add(name, value)
+		}
+	}
 	request.Header.Set("Accept", "application/json")
-	request.Header.Set("User-Agent", client.userAgent)
+	userAgent := client.userAgent
+	if client.userAgentSuffix != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, client.userAgentSuffix)
+	}
+	request.Header.Set("User-Agent", userAgent)
+	if client.acceptLanguage != "" {
+		request.Header.Set("Accept-Language", client.acceptLanguage)
+	}
+
+	if client.buildpackPriority != "" && isBuildpackRequest(passedRequest) {
+		request.Header.Set(client.buildpackPriorityHeader, client.buildpackPriority)
+	}
 
 	if passedRequest.Body != nil {
 		request.Header.Set("Content-Type", "application/json")
@@ -69,3 +100,33 @@ func (client Client) newHTTPRequest(passedRequest requestOptions) (*cloudcontrol
 	// Make sure the body is the same as the one in the request
 	return cloudcontroller.NewRequest(request, passedRequest.Body), nil
 }
+
+// isBuildpackRequest returns true if the request is for a buildpack endpoint,
+// either by name or, for the pages of a listing, by URI.
+func isBuildpackRequest(passedRequest requestOptions) bool {
+	switch passedRequest.RequestName {
+	case internal.DeleteBuildpackRequest,
+		internal.GetBuildpackDownloadRequest,
+		internal.GetBuildpackRequest,
+		internal.GetBuildpacksRequest,
+		internal.HeadBuildpackDownloadRequest,
+		internal.PostBuildpackRequest,
+		internal.PutBuildpackRequest,
+		internal.PutBuildpackBitsRequest:
+		return true
+	}
+	return strings.HasPrefix(passedRequest.URI, "/v2/buildpacks")
+}
+
+// applyTrailingSlash appends or strips the trailing slash of path.
+func applyTrailingSlash(path string, trailingSlash constant.TrailingSlash) string {
+	switch trailingSlash {
+	case constant.TrailingSlashAppend:
+		if !strings.HasSuffix(path, "/") {
+			return path + "/"
+		}
+	case constant.TrailingSlashStrip:
+		return strings.TrimRight(path, "/")
+	}
+	return path
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/internal"
 	"github.com/tedsuo/rata"
 )
@@ -31,13 +32,28 @@ type TargetSettings struct {
 // TargetCF sets the client to use the Cloud Controller specified in the
 // configuration. Any other configuration is also applied to the client.
 func (client *Client) TargetCF(settings TargetSettings) (Warnings, error) {
+	routes, err := client.routes()
+	if err != nil {
+		return nil, err
+	}
+
+	client.apiRoutes = routes
 	client.cloudControllerURL = settings.URL
-	client.router = rata.NewRequestGenerator(settings.URL, internal.APIRoutes)
+	client.router = rata.NewRequestGenerator(settings.URL, routes)
 
-	client.connection = cloudcontroller.NewConnection(cloudcontroller.Config{
-		DialTimeout:       settings.DialTimeout,
-		SkipSSLValidation: settings.SkipSSLValidation,
-	})
+	if client.baseConnection != nil {
+		client.connection = client.baseConnection
+	} else {
+		client.connection = cloudcontroller.NewConnection(cloudcontroller.Config{
+			DialTimeout:           settings.DialTimeout,
+			SkipSSLValidation:     settings.SkipSSLValidation,
+			MaxIdleConns:          client.maxIdleConns,
+			MaxIdleConnsPerHost:   client.maxIdleConnsPerHost,
+			IdleConnTimeout:       client.idleConnTimeout,
+			ProxyURL:              client.proxyURL,
+			ExpectContinueTimeout: client.uploadExpectContinueTimeout,
+		})
+	}
 
 	for _, wrapper := range client.wrappers {
 		client.connection = wrapper.Wrap(client.connection)
@@ -57,3 +73,28 @@ func (client *Client) TargetCF(settings TargetSettings) (Warnings, error) {
 
 	return warnings, nil
 }
+
+// routes returns the API routes with the client's route overrides applied.
+func (client *Client) routes() (rata.Routes, error) {
+	if len(client.routeOverrides) == 0 {
+		return internal.APIRoutes, nil
+	}
+
+	routes := make(rata.Routes, len(internal.APIRoutes))
+	copy(routes, internal.APIRoutes)
+
+	for name, path := range client.routeOverrides {
+		found := false
+		for i := range routes {
+			if routes[i].Name == name {
+				routes[i].Path = path
+				found = true
+			}
+		}
+		if !found {
+			return nil, ccerror.UnknownRouteError{Name: name}
+		}
+	}
+
+	return routes, nil
+}
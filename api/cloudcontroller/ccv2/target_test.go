@@ -1,11 +1,17 @@
 package ccv2_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/ccv2fakes"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/cloudcontrollerfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -82,6 +88,85 @@ var _ = Describe("Target", func() {
 			})
 		})
 
+		Context("when the client has a connection", func() {
+			var fakeConnection *cloudcontrollerfakes.FakeConnection
+
+			BeforeEach(func() {
+				fakeConnection = new(cloudcontrollerfakes.FakeConnection)
+				fakeConnection.MakeStub = func(request *cloudcontroller.Request, response *cloudcontroller.Response) error {
+					switch request.URL.Path {
+					case "/v2/info":
+						response.Warnings = []string{"info warning"}
+						return json.Unmarshal([]byte(`{"api_version": "2.100.0"}`), response.Result)
+					case "/v2/buildpacks/some-bp-guid/bits":
+						_, err := ioutil.ReadAll(request.Body)
+						Expect(err).ToNot(HaveOccurred())
+						response.Warnings = []string{"upload warning"}
+						return ccerror.BuildpackNotFoundError{}
+					}
+					Fail(fmt.Sprintf("unexpected request to %s", request.URL))
+					return nil
+				}
+
+				client = NewClient(Config{
+					AppName:    "CF CLI API Target Test",
+					AppVersion: "Unknown",
+					Connection: fakeConnection,
+				})
+			})
+
+			It("sends every request through it", func() {
+				warnings, err := client.TargetCF(TargetSettings{URL: "https://api.example.com"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(ConsistOf("info warning"))
+				Expect(client.APIVersion()).To(Equal("2.100.0"))
+
+				warnings, err = client.UploadBuildpack("some-bp-guid", "some-bp.zip", strings.NewReader("some-content"), 12)
+				Expect(err).To(MatchError(ccerror.BuildpackNotFoundError{}))
+				Expect(warnings).To(ConsistOf("upload warning"))
+
+				Expect(fakeConnection.MakeCallCount()).To(Equal(2))
+				request, _ := fakeConnection.MakeArgsForCall(1)
+				Expect(request.Method).To(Equal(http.MethodPut))
+				Expect(request.URL.String()).To(Equal("https://api.example.com/v2/buildpacks/some-bp-guid/bits"))
+			})
+		})
+
+		Context("when the client has route overrides", func() {
+			It("sends the overridden requests to the new path", func() {
+				client = NewClient(Config{
+					AppName:        "CF CLI API Target Test",
+					AppVersion:     "Unknown",
+					RouteOverrides: map[string]string{"GetBuildpacks": "/v2/custom_buildpacks"},
+				})
+				_, err := client.TargetCF(TargetSettings{
+					SkipSSLValidation: true,
+					URL:               server.URL(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/custom_buildpacks"),
+						RespondWith(http.StatusOK, `{"resources": []}`),
+					),
+				)
+				_, _, err = client.GetBuildpacks()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an UnknownRouteError for unknown request names", func() {
+				client = NewClient(Config{
+					RouteOverrides: map[string]string{"GetPotatoes": "/v2/potatoes"},
+				})
+				_, err := client.TargetCF(TargetSettings{
+					SkipSSLValidation: true,
+					URL:               server.URL(),
+				})
+				Expect(err).To(MatchError(ccerror.UnknownRouteError{Name: "GetPotatoes"}))
+			})
+		})
+
 		Context("when passed a valid API URL", func() {
 			BeforeEach(func() {
 				client = NewClient(Config{AppName: "CF CLI API Target Test", AppVersion: "Unknown"})
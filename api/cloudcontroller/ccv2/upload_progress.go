@@ -0,0 +1,62 @@
+package ccv2
+
+import "io"
+
+// UploadProgressFunc is called as a buildpack upload is sent with the number
+// of bytes of the request body sent so far and the total size of the body.
+// The total is -1 when the size of the body is unknown.
+type UploadProgressFunc func(bytesSent int64, totalBytes int64)
+
+// uploadProgressReader reports progress as the HTTP client reads the request
+// body.
+//
+// The buildpack bits are copied into a pipe by a separate goroutine, so
+// counting the bytes written into the pipe would report bytes that are only
+// buffered and run ahead of the upload. Counting the bytes the HTTP client
+// reads from the other end of the pipe instead only counts bytes that have
+// been handed to the connection, so when the network applies backpressure
+// the progress waits with it.
+type uploadProgressReader struct {
+	io.ReadSeeker
+	sent     int64
+	total    int64
+	progress UploadProgressFunc
+}
+
+func (r *uploadProgressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadSeeker.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.progress(r.sent, r.total)
+	}
+	return n, err
+}
+
+// Seek seeks the underlying body. When a retry rewinds the body, progress
+// starts again from the new offset.
+func (r *uploadProgressReader) Seek(offset int64, whence int) (int64, error) {
+	position, err := r.ReadSeeker.Seek(offset, whence)
+	if err == nil {
+		r.sent = position
+	}
+	return position, err
+}
+
+// Close closes the underlying body if it can be closed, so that wrapping the
+// upload pipe does not stop the HTTP client from closing it.
+func (r *uploadProgressReader) Close() error {
+	if closer, ok := r.ReadSeeker.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// withUploadProgress wraps body so that the client's upload progress function
+// is called as it is read. body is returned unchanged when there is no
+// progress function.
+func (client *Client) withUploadProgress(body io.ReadSeeker, total int64) io.ReadSeeker {
+	if client.uploadProgress == nil {
+		return body
+	}
+	return &uploadProgressReader{ReadSeeker: body, total: total, progress: client.uploadProgress}
+}
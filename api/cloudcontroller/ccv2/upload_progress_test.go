@@ -0,0 +1,83 @@
+package ccv2_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Upload Progress", func() {
+	var (
+		client *Client
+
+		mutex         sync.Mutex
+		sentBytes     []int64
+		totals        []int64
+		contentLength int64
+	)
+
+	BeforeEach(func() {
+		sentBytes = nil
+		totals = nil
+
+		client = NewTestClient().WithUploadProgress(func(bytesSent int64, totalBytes int64) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			sentBytes = append(sentBytes, bytesSent)
+			totals = append(totals, totalBytes)
+		})
+
+		server.AppendHandlers(
+			CombineHandlers(
+				VerifyRequest(http.MethodPut, "/v2/buildpacks/some-bp-guid/bits"),
+				func(_ http.ResponseWriter, req *http.Request) {
+					body, err := ioutil.ReadAll(req.Body)
+					Expect(err).ToNot(HaveOccurred())
+					contentLength = int64(len(body))
+				},
+				RespondWith(http.StatusCreated, "{}"),
+			),
+		)
+	})
+
+	Describe("UploadBuildpack", func() {
+		Context("when the length of the buildpack is known", func() {
+			It("reports the bytes of the request body sent out of the total", func() {
+				content := strings.Repeat("a", 100000)
+				_, err := client.UploadBuildpack("some-bp-guid", "buildpack.zip", strings.NewReader(content), int64(len(content)))
+				Expect(err).ToNot(HaveOccurred())
+
+				mutex.Lock()
+				defer mutex.Unlock()
+				Expect(sentBytes).ToNot(BeEmpty())
+				for i := 1; i < len(sentBytes); i++ {
+					Expect(sentBytes[i]).To(BeNumerically(">", sentBytes[i-1]))
+				}
+				Expect(sentBytes[len(sentBytes)-1]).To(Equal(contentLength))
+				for _, total := range totals {
+					Expect(total).To(Equal(contentLength))
+				}
+			})
+		})
+
+		Context("when the length of the buildpack is unknown", func() {
+			It("reports a total of -1", func() {
+				_, err := client.UploadBuildpack("some-bp-guid", "buildpack.zip", strings.NewReader("some-content"), -1)
+				Expect(err).ToNot(HaveOccurred())
+
+				mutex.Lock()
+				defer mutex.Unlock()
+				Expect(sentBytes[len(sentBytes)-1]).To(Equal(contentLength))
+				for _, total := range totals {
+					Expect(total).To(BeEquivalentTo(-1))
+				}
+			})
+		})
+	})
+})
@@ -0,0 +1,81 @@
+package ccv2
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// UploadRateLimiter is a token bucket that limits the combined throughput of
+// every upload it is attached to. A single UploadRateLimiter can be shared by
+// several clients and used by concurrent uploads.
+type UploadRateLimiter struct {
+	bytesPerSecond int64
+
+	mutex      sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+}
+
+// NewUploadRateLimiter returns an UploadRateLimiter that allows bytesPerSecond
+// bytes per second across all uploads, with bursts of up to one second's
+// worth of bytes. A bytesPerSecond of 0 or less does not limit uploads.
+func NewUploadRateLimiter(bytesPerSecond int64) *UploadRateLimiter {
+	return &UploadRateLimiter{
+		bytesPerSecond: bytesPerSecond,
+		tokens:         float64(bytesPerSecond),
+		lastRefill:     time.Now(),
+	}
+}
+
+// limits returns true if the limiter has a positive rate.
+func (limiter *UploadRateLimiter) limits() bool {
+	return limiter.bytesPerSecond > 0
+}
+
+// wait blocks until n bytes may be sent. n must not exceed bytesPerSecond.
+// Tokens are kept fractional so that frequent refills do not lose credit.
+func (limiter *UploadRateLimiter) wait(n int64) {
+	for {
+		limiter.mutex.Lock()
+		now := time.Now()
+		limiter.tokens += now.Sub(limiter.lastRefill).Seconds() * float64(limiter.bytesPerSecond)
+		if limiter.tokens > float64(limiter.bytesPerSecond) {
+			limiter.tokens = float64(limiter.bytesPerSecond)
+		}
+		limiter.lastRefill = now
+
+		if limiter.tokens >= float64(n) {
+			limiter.tokens -= float64(n)
+			limiter.mutex.Unlock()
+			return
+		}
+
+		missing := float64(n) - limiter.tokens
+		limiter.mutex.Unlock()
+		time.Sleep(time.Duration(missing / float64(limiter.bytesPerSecond) * float64(time.Second)))
+	}
+}
+
+// limitedReader reads from reader no faster than limiter allows. Each read is
+// charged for the bytes it returned, so short reads do not use up the budget.
+type limitedReader struct {
+	reader  io.Reader
+	limiter *UploadRateLimiter
+}
+
+func (r limitedReader) Read(p []byte) (int, error) {
+	if !r.limiter.limits() {
+		return r.reader.Read(p)
+	}
+
+	if int64(len(p)) > r.limiter.bytesPerSecond {
+		p = p[:r.limiter.bytesPerSecond]
+	}
+
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.limiter.wait(int64(n))
+	}
+	return n, err
+}
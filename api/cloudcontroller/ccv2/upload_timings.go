@@ -0,0 +1,121 @@
+package ccv2
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// UploadTimings breaks down where the time of a buildpack upload went. When
+// an upload is retried, the phases describe the last attempt. Phases that did
+// not happen, such as DNS and connecting when an idle connection is reused,
+// are zero.
+type UploadTimings struct {
+	// DNS is the time spent resolving the Cloud Controller's host name.
+	DNS time.Duration
+
+	// Connect is the time spent opening the TCP connection.
+	Connect time.Duration
+
+	// TLSHandshake is the time spent on the TLS handshake.
+	TLSHandshake time.Duration
+
+	// Upload is the time between obtaining a connection and finishing writing
+	// the request, including the buildpack bits.
+	Upload time.Duration
+
+	// ServerProcessing is the time between finishing writing the request and
+	// receiving the first byte of the response.
+	ServerProcessing time.Duration
+
+	// Total is the time UploadBuildpackWithTimings took, including retries.
+	Total time.Duration
+}
+
+// UploadBuildpackWithTimings behaves like UploadBuildpack, but also returns
+// how long each phase of the upload took. Timings are only collected by this
+// method, so UploadBuildpack has no tracing overhead.
+func (client *Client) UploadBuildpackWithTimings(buildpackGUID string, buildpackPath string, buildpack io.Reader, buildpackLength int64) (UploadTimings, Warnings, error) {
+	recorder := &uploadTimingsRecorder{}
+
+	tracingClient := *client
+	tracingClient.uploadTrace = recorder
+
+	start := time.Now()
+	warnings, err := tracingClient.UploadBuildpack(buildpackGUID, buildpackPath, buildpack, buildpackLength)
+
+	timings := recorder.timings()
+	timings.Total = time.Since(start)
+	return timings, warnings, err
+}
+
+// uploadTimingsRecorder records the phases of an upload from httptrace
+// callbacks, which can be called from several goroutines.
+type uploadTimingsRecorder struct {
+	mutex  sync.Mutex
+	phases uploadPhases
+}
+
+// uploadPhases are the times at which the phases of an upload attempt started
+// and ended.
+type uploadPhases struct {
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	gotConn, wroteRequest     time.Time
+	gotFirstResponseByte      time.Time
+}
+
+// clientTrace returns a trace that records a new attempt, discarding the
+// phases of any previous attempt.
+func (recorder *uploadTimingsRecorder) clientTrace() *httptrace.ClientTrace {
+	recorder.mutex.Lock()
+	recorder.phases = uploadPhases{}
+	recorder.mutex.Unlock()
+
+	record := func(phase *time.Time) {
+		recorder.mutex.Lock()
+		defer recorder.mutex.Unlock()
+		if phase.IsZero() {
+			*phase = time.Now()
+		}
+	}
+
+	phases := &recorder.phases
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { record(&phases.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { record(&phases.dnsDone) },
+		ConnectStart:         func(string, string) { record(&phases.connectStart) },
+		ConnectDone:          func(string, string, error) { record(&phases.connectDone) },
+		TLSHandshakeStart:    func() { record(&phases.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { record(&phases.tlsDone) },
+		GotConn:              func(httptrace.GotConnInfo) { record(&phases.gotConn) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { record(&phases.wroteRequest) },
+		GotFirstResponseByte: func() { record(&phases.gotFirstResponseByte) },
+	}
+}
+
+func (recorder *uploadTimingsRecorder) timings() UploadTimings {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	phases := recorder.phases
+	return UploadTimings{
+		DNS:              phaseDuration(phases.dnsStart, phases.dnsDone),
+		Connect:          phaseDuration(phases.connectStart, phases.connectDone),
+		TLSHandshake:     phaseDuration(phases.tlsStart, phases.tlsDone),
+		Upload:           phaseDuration(phases.gotConn, phases.wroteRequest),
+		ServerProcessing: phaseDuration(phases.wroteRequest, phases.gotFirstResponseByte),
+	}
+}
+
+// phaseDuration returns the time between start and end, or zero if either
+// was not recorded.
+func phaseDuration(start time.Time, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() {
+		return 0
+	}
+	return end.Sub(start)
+}
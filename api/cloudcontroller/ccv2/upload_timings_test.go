@@ -0,0 +1,76 @@
+package ccv2_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("UploadTimings", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("UploadBuildpackWithTimings", func() {
+		var (
+			timings    UploadTimings
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			content := "some-content"
+			timings, warnings, executeErr = client.UploadBuildpackWithTimings("some-buildpack-guid", "buildpack.zip", strings.NewReader(content), int64(len(content)))
+		})
+
+		Context("when the upload succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+						func(_ http.ResponseWriter, req *http.Request) {
+							_, err := ioutil.ReadAll(req.Body)
+							Expect(err).ToNot(HaveOccurred())
+							time.Sleep(50 * time.Millisecond)
+						},
+						RespondWith(http.StatusCreated, "{}", http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the time spent in each phase and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+
+				Expect(timings.ServerProcessing).To(BeNumerically(">=", 50*time.Millisecond))
+				Expect(timings.Upload).To(BeNumerically(">", 0))
+				Expect(timings.Total).To(BeNumerically(">=", timings.Upload+timings.ServerProcessing))
+			})
+		})
+
+		Context("when the upload returns an error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPut, "/v2/buildpacks/some-buildpack-guid/bits"),
+						RespondWith(http.StatusTeapot, `{"code": 10001, "description": "Some Error", "error_code": "CF-SomeError"}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error, warnings, and the total time", func() {
+				Expect(executeErr).To(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(timings.Total).To(BeNumerically(">", 0))
+			})
+		})
+	})
+})
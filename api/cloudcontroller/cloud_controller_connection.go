@@ -3,6 +3,7 @@ package cloudcontroller
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -17,8 +18,29 @@ import (
 type Config struct {
 	DialTimeout       time.Duration
 	SkipSSLValidation bool
+
+	// MaxIdleConns, MaxIdleConnsPerHost, and IdleConnTimeout tune connection
+	// reuse. Zero values use the net/http defaults.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+
+	// ProxyURL, if set, is the proxy every request is sent through. User
+	// information in the URL is sent to the proxy as Basic credentials. If
+	// nil, the proxy is taken from the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY
+	// environment variables.
+	ProxyURL *url.URL
+
+	// ExpectContinueTimeout is how long requests with an "Expect:
+	// 100-continue" header wait for the server to accept them before sending
+	// their body anyway. If zero, the body is sent without waiting.
+	ExpectContinueTimeout time.Duration
 }
 
+// maxRedirects is the number of redirects a request follows before a
+// ccerror.TooManyRedirectsError is returned.
+const maxRedirects = 10
+
 // CloudControllerConnection represents a connection to the Cloud Controller
 // server.
 type CloudControllerConnection struct {
@@ -29,22 +51,58 @@ type CloudControllerConnection struct {
 // NewConnection returns a new CloudControllerConnection with provided
 // configuration.
 func NewConnection(config Config) *CloudControllerConnection {
+	proxy := http.ProxyFromEnvironment
+	if config.ProxyURL != nil {
+		proxy = http.ProxyURL(config.ProxyURL)
+	}
+
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: config.SkipSSLValidation,
 		},
-		Proxy: http.ProxyFromEnvironment,
+		Proxy: proxy,
 		DialContext: (&net.Dialer{
 			KeepAlive: 30 * time.Second,
 			Timeout:   config.DialTimeout,
 		}).DialContext,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		ExpectContinueTimeout: config.ExpectContinueTimeout,
 	}
 
 	return &CloudControllerConnection{
-		HTTPClient: &http.Client{Transport: tr},
+		HTTPClient: &http.Client{
+			Transport:     tr,
+			CheckRedirect: checkRedirect,
+		},
 	}
 }
 
+// checkRedirect follows up to maxRedirects redirects, returning an error when
+// a URL is redirected to twice with the same method, so that a POST answered
+// with a 303 to its own URL can still be fetched with a GET. Redirects such as
+// those from buildpack downloads to the blobstore can lead to other hosts, so
+// the Authorization header is removed whenever the host differs from the
+// original request's to avoid sending the Cloud Controller token to them.
+func checkRedirect(request *http.Request, via []*http.Request) error {
+	for _, previous := range via {
+		if previous.Method == request.Method && previous.URL.String() == request.URL.String() {
+			return ccerror.RedirectLoopError{URL: request.URL.String()}
+		}
+	}
+
+	if len(via) > maxRedirects {
+		return ccerror.TooManyRedirectsError{Limit: maxRedirects, URL: request.URL.String()}
+	}
+
+	if request.URL.Host != via[0].URL.Host {
+		request.Header.Del("Authorization")
+	}
+
+	return nil
+}
+
 // Make performs the request and parses the response.
 func (connection *CloudControllerConnection) Make(request *Request, passedResponse *Response) error {
 	// In case this function is called from a retry, passedResponse may already
@@ -63,13 +121,26 @@ func (connection *CloudControllerConnection) Make(request *Request, passedRespon
 func (*CloudControllerConnection) handleStatusCodes(response *http.Response, passedResponse *Response) error {
 	if response.StatusCode == http.StatusNoContent {
 		passedResponse.RawResponse = []byte("{}")
+	} else if passedResponse.BodyWriter != nil && response.StatusCode < 400 {
+		defer response.Body.Close()
+		return copyResponseBody(passedResponse.BodyWriter, response.Body, passedResponse.MaxBodySize)
 	} else {
-		rawBytes, err := ioutil.ReadAll(response.Body)
 		defer response.Body.Close()
+
+		var body io.Reader = response.Body
+		if passedResponse.MaxBodySize > 0 {
+			body = io.LimitReader(response.Body, passedResponse.MaxBodySize+1)
+		}
+
+		rawBytes, err := ioutil.ReadAll(body)
 		if err != nil {
 			return err
 		}
 
+		if passedResponse.MaxBodySize > 0 && int64(len(rawBytes)) > passedResponse.MaxBodySize {
+			return ccerror.ResponseTooLargeError{Limit: passedResponse.MaxBodySize}
+		}
+
 		passedResponse.RawResponse = rawBytes
 	}
 
@@ -84,6 +155,30 @@ func (*CloudControllerConnection) handleStatusCodes(response *http.Response, pas
 	return nil
 }
 
+// copyResponseBody copies body to writer, returning a
+// ccerror.ResponseTooLargeError if body is larger than maxBodySize.
+func copyResponseBody(writer io.Writer, body io.Reader, maxBodySize int64) error {
+	if maxBodySize <= 0 {
+		_, err := io.Copy(writer, body)
+		return err
+	}
+
+	written, err := io.Copy(writer, io.LimitReader(body, maxBodySize))
+	if err != nil {
+		return err
+	}
+	if written == maxBodySize {
+		n, err := body.Read(make([]byte, 1))
+		if n > 0 {
+			return ccerror.ResponseTooLargeError{Limit: maxBodySize}
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+	}
+	return nil
+}
+
 // handleWarnings looks for the "X-Cf-Warnings" header in the cloud controller
 // response and URI decodes them. The value can contain multiple warnings that
 // are comma separated.
@@ -148,6 +243,8 @@ func (*CloudControllerConnection) processRequestErrors(request *http.Request, er
 			return ccerror.SSLValidationHostnameError{
 				Message: urlErr.Error(),
 			}
+		case ccerror.RedirectLoopError, ccerror.TooManyRedirectsError:
+			return urlErr
 		default:
 			return ccerror.RequestError{Err: e}
 		}
@@ -1,6 +1,9 @@
 package cloudcontroller
 
-import "net/http"
+import (
+	"io"
+	"net/http"
+)
 
 // Response represents a Cloud Controller response object.
 type Response struct {
@@ -20,6 +23,18 @@ type Response struct {
 
 	// ResourceLocationURL represents the Location header value
 	ResourceLocationURL string
+
+	// MaxBodySize limits the size of the response body that is read. A larger
+	// body results in a ccerror.ResponseTooLargeError. Zero or less means no
+	// limit.
+	MaxBodySize int64
+
+	// BodyWriter, if set, receives the body of a successful response as it is
+	// read, so that large bodies are not held in memory. RawResponse is left
+	// empty and Result is not decoded. Error responses are still read into
+	// RawResponse. MaxBodySize still applies, although the bytes up to the
+	// limit will already have been written.
+	BodyWriter io.Writer
 }
 
 func (r *Response) reset() {
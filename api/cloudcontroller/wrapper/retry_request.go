@@ -7,8 +7,9 @@ import (
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 )
 
-// RetryRequest is a wrapper that retries failed requests if they contain a 5XX
-// status code.
+// RetryRequest is a wrapper that retries requests that fail without a
+// response or with a status code that ccerror.IsRetryableStatusCode
+// classifies as retryable.
 type RetryRequest struct {
 	maxRetries int
 	connection cloudcontroller.Connection
@@ -21,7 +22,8 @@ func NewRetryRequest(maxRetries int) *RetryRequest {
 	}
 }
 
-// Make retries the request if it comes back with a 5XX status code.
+// Make retries the request if it comes back with a 5XX or 429 status code, or
+// fails without a response.
 func (retry *RetryRequest) Make(request *cloudcontroller.Request, passedResponse *cloudcontroller.Response) error {
 	var err error
 
@@ -53,13 +55,12 @@ func (retry *RetryRequest) Wrap(innerconnection cloudcontroller.Connection) clou
 	return retry
 }
 
-// skipRetry will skip retry if the request method is POST or contains a status
-// code that is not one of following http status codes: 500, 502, 503, 504.
+// skipRetry will skip retry if the request method is POST, since the request
+// may already have created a resource, or if the status code is not
+// retryable.
 func (*RetryRequest) skipRetry(httpMethod string, response *http.Response) bool {
-	return httpMethod == http.MethodPost ||
-		response != nil &&
-			response.StatusCode != http.StatusInternalServerError &&
-			response.StatusCode != http.StatusBadGateway &&
-			response.StatusCode != http.StatusServiceUnavailable &&
-			response.StatusCode != http.StatusGatewayTimeout
+	if httpMethod == http.MethodPost {
+		return true
+	}
+	return response != nil && !ccerror.IsRetryableStatusCode(response.StatusCode)
 }
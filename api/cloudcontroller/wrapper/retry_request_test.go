@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
@@ -53,6 +54,8 @@ var _ = Describe("Retry Request", func() {
 		Entry("maxRetries for Non-Post (502) Bad Gateway", http.MethodGet, http.StatusBadGateway, 3),
 		Entry("maxRetries for Non-Post (503) Service Unavailable", http.MethodGet, http.StatusServiceUnavailable, 3),
 		Entry("maxRetries for Non-Post (504) Gateway Timeout", http.MethodGet, http.StatusGatewayTimeout, 3),
+		Entry("maxRetries for Non-Post (501) Not Implemented", http.MethodGet, http.StatusNotImplemented, 3),
+		Entry("maxRetries for Non-Post (429) Too Many Requests", http.MethodGet, http.StatusTooManyRequests, 3),
 
 		Entry("1 for Post (500) Internal Server Error", http.MethodPost, http.StatusInternalServerError, 1),
 		Entry("1 for Post (502) Bad Gateway", http.MethodPost, http.StatusBadGateway, 1),
@@ -62,6 +65,61 @@ var _ = Describe("Retry Request", func() {
 		Entry("1 for Get 4XX Errors", http.MethodGet, http.StatusNotFound, 1),
 	)
 
+	DescribeTable("agrees with ccerror.IsRetryable",
+		func(statusCode int) {
+			req, err := http.NewRequest(http.MethodGet, "https://foo.bar.com/banana", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request := cloudcontroller.NewRequest(req, nil)
+			response := &cloudcontroller.Response{
+				HTTPResponse: &http.Response{
+					StatusCode: statusCode,
+				},
+			}
+
+			expectedErr := ccerror.RawHTTPStatusError{StatusCode: statusCode}
+			fakeConnection := new(cloudcontrollerfakes.FakeConnection)
+			fakeConnection.MakeReturns(expectedErr)
+
+			err = NewRetryRequest(1).Wrap(fakeConnection).Make(request, response)
+			Expect(err).To(MatchError(expectedErr))
+			Expect(fakeConnection.MakeCallCount() > 1).To(Equal(ccerror.IsRetryable(expectedErr)))
+		},
+		Entry("400", http.StatusBadRequest),
+		Entry("409", http.StatusConflict),
+		Entry("429", http.StatusTooManyRequests),
+		Entry("500", http.StatusInternalServerError),
+		Entry("501", http.StatusNotImplemented),
+		Entry("505", http.StatusHTTPVersionNotSupported),
+	)
+
+	Context("when the request fails without a response", func() {
+		var (
+			request        *cloudcontroller.Request
+			fakeConnection *cloudcontrollerfakes.FakeConnection
+		)
+
+		BeforeEach(func() {
+			req, err := http.NewRequest(http.MethodGet, "https://foo.bar.com/banana", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request = cloudcontroller.NewRequest(req, nil)
+			fakeConnection = new(cloudcontrollerfakes.FakeConnection)
+		})
+
+		It("retries timeouts", func() {
+			fakeConnection.MakeReturns(ccerror.RequestError{Err: &url.Error{Op: "Get", URL: "https://foo.bar.com/banana", Err: timeoutError{}}})
+
+			_ = NewRetryRequest(2).Wrap(fakeConnection).Make(request, &cloudcontroller.Response{})
+			Expect(fakeConnection.MakeCallCount()).To(Equal(3))
+		})
+
+		It("retries other network errors", func() {
+			fakeConnection.MakeReturns(ccerror.RequestError{Err: &url.Error{Op: "Get", URL: "https://foo.bar.com/banana", Err: errors.New("connection refused")}})
+
+			_ = NewRetryRequest(2).Wrap(fakeConnection).Make(request, &cloudcontroller.Response{})
+			Expect(fakeConnection.MakeCallCount()).To(Equal(3))
+		})
+	})
+
 	It("does not retry on success", func() {
 		req, err := http.NewRequest(http.MethodGet, "https://foo.bar.com/banana", nil)
 		Expect(err).NotTo(HaveOccurred())
@@ -115,3 +173,9 @@ var _ = Describe("Retry Request", func() {
 		})
 	})
 })
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }